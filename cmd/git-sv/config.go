@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
 	"strings"
 
@@ -33,20 +37,54 @@ type Config struct {
 	Version       string                 `yaml:"version"`
 	Versioning    sv.VersioningConfig    `yaml:"versioning"`
 	Tag           sv.TagConfig           `yaml:"tag"`
+	Log           sv.LogConfig           `yaml:"log"`
+	Cache         sv.CacheConfig         `yaml:"cache"`
 	ReleaseNotes  sv.ReleaseNotesConfig  `yaml:"release-notes"`
 	Branches      sv.BranchesConfig      `yaml:"branches"`
 	CommitMessage sv.CommitMessageConfig `yaml:"commit-message"`
 }
 
-func getRepoPath() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+func getRepoPath(ctx context.Context, path string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel")
+	cmd.Dir = path
 	out, err := cmd.CombinedOutput()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", ctxErr
+	}
 	if err != nil {
 		return "", combinedOutputErr(err, out)
 	}
 	return strings.TrimSpace(string(out)), nil
 }
 
+// getCacheDir cache dir inside git dir, shared by all worktrees.
+func getCacheDir(ctx context.Context, repoPath string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--git-common-dir")
+	cmd.Dir = repoPath
+	out, err := cmd.CombinedOutput()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", ctxErr
+	}
+	if err != nil {
+		return "", combinedOutputErr(err, out)
+	}
+
+	gitDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(repoPath, gitDir)
+	}
+	return filepath.Join(gitDir, cacheDir), nil
+}
+
+func configHash(cfg Config) (string, error) {
+	content, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func combinedOutputErr(err error, out []byte) error {
 	msg := strings.Split(string(out), "\n")
 	return fmt.Errorf("%v - %s", err, msg[0])
@@ -83,6 +121,16 @@ func defaultConfig() Config {
 			Pattern: &pattern,
 			Filter:  &filter,
 		},
+		Log: sv.LogConfig{
+			Files:       false,
+			Lenient:     false,
+			FirstParent: false,
+			Merges:      sv.LogMergesInclude,
+			MergeBody:   false,
+		},
+		Cache: sv.CacheConfig{
+			Enabled: false,
+		},
 		ReleaseNotes: sv.ReleaseNotesConfig{
 			Sections: []sv.ReleaseNotesSectionConfig{
 				{Name: "Features", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}},
@@ -152,6 +200,8 @@ func migrateConfig(cfg Config, filename string) Config {
 		Version:    cfg.Version,
 		Versioning: cfg.Versioning,
 		Tag:        cfg.Tag,
+		Log:        cfg.Log,
+		Cache:      cfg.Cache,
 		ReleaseNotes: sv.ReleaseNotesConfig{
 			Sections: migrateReleaseNotesConfig(cfg.ReleaseNotes.Headers),
 		},
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"os/exec"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/bvieira/sv4git/v2/sv"
+	"github.com/bvieira/sv4git/v2/svtest"
 )
 
 func Test_merge(t *testing.T) {
@@ -50,3 +54,18 @@ func Test_merge(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadInvalidLogConfig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found on PATH")
+	}
+	t.Setenv("SV4GIT_HOME", "")
+
+	r := svtest.NewRepo(t)
+	r.WriteFile(repoConfigFilename, "log:\n    merges: none\n").Commit("chore: add config")
+
+	err := (&dependencies{}).load(context.Background(), r.Path())
+	if err == nil || !strings.Contains(err.Error(), "invalid log merges: none") {
+		t.Errorf("dependencies.load() error = %v, want invalid log merges", err)
+	}
+}
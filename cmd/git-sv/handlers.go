@@ -1,13 +1,14 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
-	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/bvieira/sv4git/v2/sv"
@@ -38,40 +39,85 @@ func configShowHandler(cfg Config) func(c *cli.Context) error {
 	}
 }
 
-func currentVersionHandler(git sv.Git) func(c *cli.Context) error {
+func cacheClearHandler(dir string) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		lastTag := git.LastTag()
+		if err := sv.ClearCache(dir); err != nil {
+			return fmt.Errorf("could not clear cache, error: %v", err)
+		}
+		return nil
+	}
+}
+
+func currentVersionHandler(git sv.GitContext, releaser sv.Releaser) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		output := c.String("output")
+		if output == outputText {
+			lastTag, err := git.LastTagContext(c.Context)
+			if err != nil {
+				return fmt.Errorf("error getting last tag, message: %v", err)
+			}
+
+			currentVer, err := sv.ToVersion(lastTag)
+			if err != nil {
+				return fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
+			}
+			fmt.Printf("%d.%d.%d\n", currentVer.Major(), currentVer.Minor(), currentVer.Patch())
+			return nil
+		}
+
+		info, err := releaser.NextVersion(c.Context)
+		if err != nil {
+			return err
+		}
+		warnNonConventional(info.Commits)
+		return printVersionInfo(info, output)
+	}
+}
 
-		currentVer, err := sv.ToVersion(lastTag)
+func nextVersionHandler(releaser sv.Releaser) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		info, err := releaser.NextVersion(c.Context)
 		if err != nil {
-			return fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
+			return err
 		}
-		fmt.Printf("%d.%d.%d\n", currentVer.Major(), currentVer.Minor(), currentVer.Patch())
+		warnNonConventional(info.Commits)
+
+		if output := c.String("output"); output != outputText {
+			return printVersionInfo(info, output)
+		}
+		fmt.Printf("%d.%d.%d\n", info.Next.Major(), info.Next.Minor(), info.Next.Patch())
 		return nil
 	}
 }
 
-func nextVersionHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) func(c *cli.Context) error {
+func versionsHandler(releaser sv.Releaser) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		lastTag := git.LastTag()
+		opts := sv.VersionsOptions{LatestPerMajor: c.Bool("latest-per-major"), LatestPerMinor: c.Bool("latest-per-minor")}
+		if constraint := c.String("constraint"); constraint != "" {
+			versions, err := semver.NewConstraint(constraint)
+			if err != nil {
+				return fmt.Errorf("invalid version constraint: %s, error: %v", constraint, err)
+			}
+			opts.Constraint = versions
+		}
 
-		currentVer, err := sv.ToVersion(lastTag)
+		versions, err := releaser.Versions(c.Context, opts)
 		if err != nil {
-			return fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
+			return err
 		}
 
-		commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, ""))
+		output, err := formatVersions(versions, c.String("output"))
 		if err != nil {
-			return fmt.Errorf("error getting git log, message: %v", err)
+			return err
+		}
+		if output != "" {
+			fmt.Println(output)
 		}
-
-		nextVer, _ := semverProcessor.NextVersion(currentVer, commits)
-		fmt.Printf("%d.%d.%d\n", nextVer.Major(), nextVer.Minor(), nextVer.Patch())
 		return nil
 	}
 }
 
-func commitLogHandler(git sv.Git) func(c *cli.Context) error {
+func commitLogHandler(git sv.GitContext, releaser sv.Releaser) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
 		var commits []sv.GitCommitLog
 		var err error
@@ -84,13 +130,13 @@ func commitLogHandler(git sv.Git) func(c *cli.Context) error {
 		}
 
 		if tagFlag != "" {
-			commits, err = getTagCommits(git, tagFlag)
+			commits, err = releaser.TagCommits(c.Context, tagFlag)
 		} else {
-			r, rerr := logRange(git, rangeFlag, startFlag, endFlag)
+			r, rerr := logRange(c.Context, git, releaser, rangeFlag, startFlag, endFlag)
 			if rerr != nil {
 				return rerr
 			}
-			commits, err = git.Log(r)
+			commits, err = git.LogContext(c.Context, r)
 		}
 		if err != nil {
 			return fmt.Errorf("error getting git log, message: %v", err)
@@ -107,47 +153,42 @@ func commitLogHandler(git sv.Git) func(c *cli.Context) error {
 	}
 }
 
-func getTagCommits(git sv.Git, tag string) ([]sv.GitCommitLog, error) {
-	prev, _, err := getTags(git, tag)
-	if err != nil {
-		return nil, err
-	}
-	return git.Log(sv.NewLogRange(sv.TagRange, prev, tag))
-}
-
-func logRange(git sv.Git, rangeFlag, startFlag, endFlag string) (sv.LogRange, error) {
+func logRange(ctx context.Context, git sv.GitContext, releaser sv.Releaser, rangeFlag, startFlag, endFlag string) (sv.LogRange, error) {
 	switch rangeFlag {
 	case string(sv.TagRange):
-		return sv.NewLogRange(sv.TagRange, str(startFlag, git.LastTag()), endFlag), nil
+		if startFlag == "" {
+			lastTag, err := git.LastTagContext(ctx)
+			if err != nil {
+				return sv.LogRange{}, fmt.Errorf("error getting last tag, message: %v", err)
+			}
+			startFlag = lastTag
+		}
+		return sv.NewLogRange(sv.TagRange, startFlag, endFlag), nil
 	case string(sv.DateRange):
 		return sv.NewLogRange(sv.DateRange, startFlag, endFlag), nil
 	case string(sv.HashRange):
 		return sv.NewLogRange(sv.HashRange, startFlag, endFlag), nil
+	case string(sv.VersionRange):
+		return releaser.ResolveVersionRange(ctx, sv.NewLogRange(sv.VersionRange, startFlag, endFlag))
 	default:
-		return sv.LogRange{}, fmt.Errorf("invalid range: %s, expected: %s, %s or %s", rangeFlag, sv.TagRange, sv.DateRange, sv.HashRange)
+		return sv.LogRange{}, fmt.Errorf("invalid range: %s, expected: %s, %s, %s or %s", rangeFlag, sv.TagRange, sv.DateRange, sv.HashRange, sv.VersionRange)
 	}
 }
 
-func commitNotesHandler(git sv.Git, rnProcessor sv.ReleaseNoteProcessor, outputFormatter sv.OutputFormatter) func(c *cli.Context) error {
+func commitNotesHandler(git sv.GitContext, releaser sv.Releaser, outputFormatter sv.OutputFormatter) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		var date time.Time
-
-		rangeFlag := c.String("r")
-		lr, err := logRange(git, rangeFlag, c.String("s"), c.String("e"))
+		lr, err := logRange(c.Context, git, releaser, c.String("r"), c.String("s"), c.String("e"))
 		if err != nil {
 			return err
 		}
 
-		commits, err := git.Log(lr)
+		release, err := releaser.RangeNote(c.Context, lr)
 		if err != nil {
-			return fmt.Errorf("error getting git log from range: %s, message: %v", rangeFlag, err)
-		}
-
-		if len(commits) > 0 {
-			date, _ = time.Parse("2006-01-02", commits[0].Date)
+			return err
 		}
+		warnNonConventional(release.Commits)
 
-		output, err := outputFormatter.FormatReleaseNote(rnProcessor.Create(nil, "", date, commits))
+		output, err := outputFormatter.FormatReleaseNote(release.ReleaseNote)
 		if err != nil {
 			return fmt.Errorf("could not format release notes, message: %v", err)
 		}
@@ -156,27 +197,15 @@ func commitNotesHandler(git sv.Git, rnProcessor sv.ReleaseNoteProcessor, outputF
 	}
 }
 
-func releaseNotesHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, rnProcessor sv.ReleaseNoteProcessor, outputFormatter sv.OutputFormatter) func(c *cli.Context) error {
+func releaseNotesHandler(releaser sv.Releaser, outputFormatter sv.OutputFormatter) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		var commits []sv.GitCommitLog
-		var rnVersion *semver.Version
-		var tag string
-		var date time.Time
-		var err error
-
-		if tag = c.String("t"); tag != "" {
-			rnVersion, date, commits, err = getTagVersionInfo(git, tag)
-		} else {
-			// TODO: should generate release notes if version was not updated?
-			rnVersion, _, date, commits, err = getNextVersionInfo(git, semverProcessor)
-		}
-
+		release, err := releaser.ReleaseNote(c.Context, sv.ReleaseNoteOptions{Tag: c.String("t")})
 		if err != nil {
 			return err
 		}
+		warnNonConventional(release.Commits)
 
-		releasenote := rnProcessor.Create(rnVersion, tag, date, commits)
-		output, err := outputFormatter.FormatReleaseNote(releasenote)
+		output, err := outputFormatter.FormatReleaseNote(release.ReleaseNote)
 		if err != nil {
 			return fmt.Errorf("could not format release notes, message: %v", err)
 		}
@@ -185,82 +214,18 @@ func releaseNotesHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor,
 	}
 }
 
-func getTagVersionInfo(git sv.Git, tag string) (*semver.Version, time.Time, []sv.GitCommitLog, error) {
-	tagVersion, _ := sv.ToVersion(tag)
-
-	previousTag, currentTag, err := getTags(git, tag)
-	if err != nil {
-		return nil, time.Time{}, nil, fmt.Errorf("error listing tags, message: %v", err)
-	}
-
-	commits, err := git.Log(sv.NewLogRange(sv.TagRange, previousTag, tag))
-	if err != nil {
-		return nil, time.Time{}, nil, fmt.Errorf("error getting git log from tag: %s, message: %v", tag, err)
-	}
-
-	return tagVersion, currentTag.Date, commits, nil
-}
-
-func getTags(git sv.Git, tag string) (string, sv.GitTag, error) {
-	tags, err := git.Tags()
-	if err != nil {
-		return "", sv.GitTag{}, err
-	}
-
-	index := find(tag, tags)
-	if index < 0 {
-		return "", sv.GitTag{}, fmt.Errorf("tag: %s not found, check tag filter", tag)
-	}
-
-	previousTag := ""
-	if index > 0 {
-		previousTag = tags[index-1].Name
-	}
-	return previousTag, tags[index], nil
-}
-
-func find(tag string, tags []sv.GitTag) int {
-	for i := 0; i < len(tags); i++ {
-		if tag == tags[i].Name {
-			return i
-		}
-	}
-	return -1
-}
-
-func getNextVersionInfo(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) (*semver.Version, bool, time.Time, []sv.GitCommitLog, error) {
-	lastTag := git.LastTag()
-
-	commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, ""))
-	if err != nil {
-		return nil, false, time.Time{}, nil, fmt.Errorf("error getting git log, message: %v", err)
-	}
-
-	currentVer, _ := sv.ToVersion(lastTag)
-	version, updated := semverProcessor.NextVersion(currentVer, commits)
-
-	return version, updated, time.Now(), commits, nil
-}
-
-func tagHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor) func(c *cli.Context) error {
+func tagHandler(git sv.GitContext, releaser sv.Releaser) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		lastTag := git.LastTag()
-
-		currentVer, err := sv.ToVersion(lastTag)
-		if err != nil {
-			return fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
-		}
-
-		commits, err := git.Log(sv.NewLogRange(sv.TagRange, lastTag, ""))
+		info, err := releaser.NextVersion(c.Context)
 		if err != nil {
-			return fmt.Errorf("error getting git log, message: %v", err)
+			return err
 		}
+		warnNonConventional(info.Commits)
 
-		nextVer, _ := semverProcessor.NextVersion(currentVer, commits)
-		tagname, err := git.Tag(*nextVer)
+		tagname, err := git.TagContext(c.Context, *info.Next)
 		fmt.Println(tagname)
 		if err != nil {
-			return fmt.Errorf("error generating tag version: %s, message: %v", nextVer.String(), err)
+			return fmt.Errorf("error generating tag version: %s, message: %v", info.Next.String(), err)
 		}
 		return nil
 	}
@@ -345,7 +310,7 @@ func getCommitBreakingChange(noBreaking bool, input string) (string, error) {
 	return promptBreakingChanges()
 }
 
-func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
+func commitHandler(cfg Config, git sv.GitContext, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
 		noBreaking := c.Bool("no-breaking")
 		noBody := c.Bool("no-body")
@@ -376,7 +341,7 @@ func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor)
 			return err
 		}
 
-		issue, err := getCommitIssue(cfg, messageProcessor, git.Branch(), noIssue)
+		issue, err := getCommitIssue(cfg, messageProcessor, git.BranchContext(c.Context), noIssue)
 		if err != nil {
 			return err
 		}
@@ -388,7 +353,7 @@ func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor)
 
 		header, body, footer := messageProcessor.Format(sv.NewCommitMessage(ctype, scope, subject, fullBody, issue, breakingChange))
 
-		err = git.Commit(header, body, footer)
+		err = git.CommitContext(c.Context, header, body, footer)
 		if err != nil {
 			return fmt.Errorf("error executing git commit, message: %v", err)
 		}
@@ -396,58 +361,66 @@ func commitHandler(cfg Config, git sv.Git, messageProcessor sv.MessageProcessor)
 	}
 }
 
-func changelogHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, rnProcessor sv.ReleaseNoteProcessor, formatter sv.OutputFormatter) func(c *cli.Context) error {
+func changelogHandler(releaser sv.Releaser, formatter sv.OutputFormatter) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		tags, err := git.Tags()
+		opts := sv.ChangelogOptions{
+			Size:                c.Int("size"),
+			All:                 c.Bool("all"),
+			AddNextVersion:      c.Bool("add-next-version"),
+			SemanticVersionOnly: c.Bool("semantic-version-only"),
+		}
+		if from, to := c.String("from"), c.String("to"); from != "" || to != "" {
+			versions, err := sv.NewVersionConstraint(from, to)
+			if err != nil {
+				return err
+			}
+			opts.Versions = versions
+		}
+
+		releases, err := releaser.Changelog(c.Context, opts)
 		if err != nil {
 			return err
 		}
-		sort.Slice(tags, func(i, j int) bool {
-			return tags[i].Date.After(tags[j].Date)
-		})
-
-		var releaseNotes []sv.ReleaseNote
 
-		size := c.Int("size")
-		all := c.Bool("all")
-		addNextVersion := c.Bool("add-next-version")
-		semanticVersionOnly := c.Bool("semantic-version-only")
+		releaseNotes := make([]sv.ReleaseNote, len(releases))
+		var allCommits []sv.GitCommitLog
+		for i, release := range releases {
+			releaseNotes[i] = release.ReleaseNote
+			allCommits = append(allCommits, release.Commits...)
+		}
+		warnNonConventional(allCommits)
 
-		if addNextVersion {
-			rnVersion, updated, date, commits, uerr := getNextVersionInfo(git, semverProcessor)
-			if uerr != nil {
-				return uerr
-			}
-			if updated {
-				releaseNotes = append(releaseNotes, rnProcessor.Create(rnVersion, "", date, commits))
-			}
+		output, err := formatter.FormatChangelog(releaseNotes)
+		if err != nil {
+			return fmt.Errorf("could not format changelog, message: %v", err)
 		}
-		for i, tag := range tags {
-			if !all && i >= size {
-				break
-			}
+		fmt.Println(output)
 
-			previousTag := ""
-			if i+1 < len(tags) {
-				previousTag = tags[i+1].Name
-			}
+		return nil
+	}
+}
 
-			if semanticVersionOnly && !sv.IsValidVersion(tag.Name) {
-				continue
-			}
+func upgradeNotesHandler(releaser sv.Releaser, outputFormatter sv.OutputFormatter) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		formatter, ok := outputFormatter.(sv.UpgradeNoteFormatter)
+		if !ok {
+			return errors.New("output formatter does not support upgrade notes")
+		}
 
-			commits, err := git.Log(sv.NewLogRange(sv.TagRange, previousTag, tag.Name))
-			if err != nil {
-				return fmt.Errorf("error getting git log from tag: %s, message: %v", tag.Name, err)
-			}
+		note, err := releaser.UpgradeNote(c.Context, sv.UpgradeNoteOptions{From: c.String("from"), To: c.String("to")})
+		if err != nil {
+			return err
+		}
 
-			currentVer, _ := sv.ToVersion(tag.Name)
-			releaseNotes = append(releaseNotes, rnProcessor.Create(currentVer, tag.Name, tag.Date, commits))
+		var allCommits []sv.GitCommitLog
+		for _, release := range note.Releases {
+			allCommits = append(allCommits, release.Commits...)
 		}
+		warnNonConventional(allCommits)
 
-		output, err := formatter.FormatChangelog(releaseNotes)
+		output, err := formatter.FormatUpgradeNote(note)
 		if err != nil {
-			return fmt.Errorf("could not format changelog, message: %v", err)
+			return fmt.Errorf("could not format upgrade notes, message: %v", err)
 		}
 		fmt.Println(output)
 
@@ -455,10 +428,28 @@ func changelogHandler(git sv.Git, semverProcessor sv.SemVerCommitsProcessor, rnP
 	}
 }
 
-func validateCommitMessageHandler(git sv.Git, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
+func warnNonConventional(commits []sv.GitCommitLog) {
+	var nonConventional []sv.GitCommitLog
+	for _, commit := range commits {
+		if commit.Message.NonConventional {
+			nonConventional = append(nonConventional, commit)
+		}
+	}
+	if len(nonConventional) == 0 {
+		return
+	}
+
+	var summary strings.Builder
+	for _, commit := range nonConventional {
+		summary.WriteString(fmt.Sprintf("\n  - %s %s", commit.Hash, commit.Message.Description))
+	}
+	warnf("found %d non-conventional commit(s):%s", len(nonConventional), summary.String())
+}
+
+func validateCommitMessageHandler(git sv.GitContext, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		branch := git.Branch()
-		detached, derr := git.IsDetached()
+		branch := git.BranchContext(c.Context)
+		detached, derr := git.IsDetachedContext(c.Context)
 
 		if messageProcessor.SkipBranch(branch, derr == nil && detached) {
 			warnf("commit message validation skipped, branch in ignore list or detached...")
@@ -477,7 +468,13 @@ func validateCommitMessageHandler(git sv.Git, messageProcessor sv.MessageProcess
 			return fmt.Errorf("failed to read commit message, error: %s", err.Error())
 		}
 
-		if err := messageProcessor.Validate(commitMessage); err != nil {
+		if c.Bool("fix") {
+			if commitMessage, err = fixCommitMessageFile(messageProcessor, commitMessage, filepath); err != nil {
+				return err
+			}
+		}
+
+		if err := lintCommitMessage(messageProcessor, commitMessage); err != nil {
 			return fmt.Errorf("invalid commit message, error: %s", err.Error())
 		}
 
@@ -498,6 +495,148 @@ func validateCommitMessageHandler(git sv.Git, messageProcessor sv.MessageProcess
 	}
 }
 
+func validateHandler(git sv.GitContext, messageProcessor sv.MessageProcessor) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		result, err := validateSource(c, git, messageProcessor)
+		if err != nil {
+			return err
+		}
+
+		count := countErrorViolations(result)
+		output, err := formatValidation(result, count == 0, c.String("format"))
+		if err != nil {
+			return err
+		}
+		if output != "" {
+			fmt.Println(output)
+		}
+
+		if count > 0 {
+			return fmt.Errorf("found %d invalid message(s) of %d validated", count, len(result))
+		}
+		return nil
+	}
+}
+
+// validateSource validate messages from the source defined by flags.
+func validateSource(c *cli.Context, git sv.GitContext, messageProcessor sv.MessageProcessor) ([]sv.CommitViolations, error) {
+	sources := 0
+	for _, flag := range []string{"range", "since-tag", "message", "stdin", "file"} {
+		if c.IsSet(flag) {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, errors.New("define messages to validate using one of range, since-tag, message, stdin or file flags")
+	}
+	if c.Bool("fix") && !c.IsSet("file") {
+		return nil, errors.New("fix flag can only be used with file flag")
+	}
+
+	switch {
+	case c.IsSet("message"):
+		return lintMessage(messageProcessor, c.String("message"))
+	case c.IsSet("stdin"):
+		content, err := io.ReadAll(c.App.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message from stdin, error: %v", err)
+		}
+		return lintMessage(messageProcessor, string(content))
+	case c.IsSet("file"):
+		message, err := readFile(c.String("file"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit message, error: %s", err.Error())
+		}
+		if c.Bool("fix") {
+			if message, err = fixCommitMessageFile(messageProcessor, message, c.String("file")); err != nil {
+				return nil, err
+			}
+		}
+		return lintMessage(messageProcessor, message)
+	}
+
+	var lr sv.LogRange
+	if revisionRange := c.String("range"); revisionRange != "" {
+		lr = revisionLogRange(revisionRange)
+	} else {
+		lastTag, err := git.LastTagContext(c.Context)
+		if err != nil {
+			return nil, fmt.Errorf("error getting last tag, message: %v", err)
+		}
+		lr = sv.NewLogRange(sv.TagRange, lastTag, "")
+	}
+	commits, err := git.LogContext(c.Context, lr)
+	if err != nil {
+		return nil, fmt.Errorf("error getting git log, message: %v", err)
+	}
+	return sv.LintCommits(messageProcessor, commits)
+}
+
+// revisionLogRange convert "start..end" git revision range to a log range.
+func revisionLogRange(revisionRange string) sv.LogRange {
+	if start, end, found := strings.Cut(revisionRange, ".."); found {
+		return sv.NewLogRange(sv.HashRange, start, end)
+	}
+	return sv.NewLogRange(sv.HashRange, "", revisionRange)
+}
+
+func lintMessage(messageProcessor sv.MessageProcessor, message string) ([]sv.CommitViolations, error) {
+	violations, err := messageProcessor.Lint(message)
+	if err != nil {
+		return nil, err
+	}
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return []sv.CommitViolations{{Subject: strings.TrimSpace(subject), Violations: violations}}, nil
+}
+
+func countErrorViolations(commits []sv.CommitViolations) int {
+	count := 0
+	for _, commit := range commits {
+		for _, v := range commit.Violations {
+			if v.Severity == sv.RuleSeverityError {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// lintCommitMessage print warning violations and return an error with all error violations.
+func lintCommitMessage(messageProcessor sv.MessageProcessor, commitMessage string) error {
+	violations, err := messageProcessor.Lint(commitMessage)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, v := range violations {
+		if v.Severity == sv.RuleSeverityWarning {
+			warnf("%s: %s", v.Rule, violationText(v))
+			continue
+		}
+		errs = append(errs, violationText(v))
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// fixCommitMessageFile apply suggested corrections on commit message and rewrite file if message changed.
+func fixCommitMessageFile(messageProcessor sv.MessageProcessor, message, filepath string) (string, error) {
+	fixed, err := messageProcessor.Fix(message)
+	if err != nil || fixed == message {
+		return message, err
+	}
+	if err := os.WriteFile(filepath, []byte(fixed), 0644); err != nil {
+		return "", fmt.Errorf("failed to write fixed commit message, error: %s", err.Error())
+	}
+	subject, _, _ := strings.Cut(fixed, "\n")
+	warnf("commit message fixed: %s", subject)
+	return fixed, nil
+}
+
 func readFile(filepath string) (string, error) {
 	f, err := os.ReadFile(filepath)
 	if err != nil {
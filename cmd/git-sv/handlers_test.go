@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bvieira/sv4git/v2/sv"
+	"github.com/bvieira/sv4git/v2/svtest"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_nextVersionHandlerNonConventionalWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		lenient  bool
+		wantWarn string
+	}{
+		{"strict", false, ""},
+		{"lenient", true, "WARN: found 1 non-conventional commit(s):"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.Log.Lenient = tt.lenient
+			mp := sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches)
+			git := svtest.NewGit(mp, cfg.Tag, cfg.Log)
+			git.AddCommit("feat: something")
+			git.AddCommit("Merge branch 'x'")
+			releaser := sv.NewReleaser(git, sv.NewSemVerCommitsProcessor(cfg.Versioning, cfg.CommitMessage), sv.NewReleaseNoteProcessor(cfg.ReleaseNotes), cfg.Tag)
+
+			var warnings bytes.Buffer
+			warnOutput = &warnings
+			defer func() { warnOutput = os.Stderr }()
+
+			app := &cli.App{Commands: []*cli.Command{{Name: "nv", Flags: []cli.Flag{outputFlag()}, Action: nextVersionHandler(releaser)}}}
+			if err := app.Run([]string{"git-sv", "nv"}); err != nil {
+				t.Fatalf("nextVersionHandler() error = %v", err)
+			}
+			if got := warnings.String(); (tt.wantWarn == "" && got != "") || !strings.HasPrefix(got, tt.wantWarn) {
+				t.Errorf("nextVersionHandler() warnings = %q, want prefix %q", got, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 )
 
+// warnOutput destination of warnings, replaced on tests.
+var warnOutput io.Writer = os.Stderr
+
 func warnf(format string, values ...interface{}) {
-	fmt.Fprintf(os.Stderr, "WARN: "+format+"\n", values...)
+	fmt.Fprintf(warnOutput, "WARN: "+format+"\n", values...)
 }
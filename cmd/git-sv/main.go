@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
 	"embed"
+	"errors"
+	"fmt"
 	"io/fs"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"syscall"
 
 	"github.com/bvieira/sv4git/v2/sv"
 	"github.com/urfave/cli/v2"
@@ -18,6 +24,7 @@ const (
 	configFilename     = "config.yml"
 	repoConfigFilename = ".sv4git.yml"
 	configDir          = ".sv4git"
+	cacheDir           = "sv4git"
 )
 
 var (
@@ -26,32 +33,83 @@ var (
 )
 
 func templateFS(filepath string) fs.FS {
+	defaultTemplatesFS, _ := fs.Sub(defaultTemplatesFS, "resources/templates")
 	if _, err := os.Stat(filepath); err != nil {
-		defaultTemplatesFS, _ := fs.Sub(defaultTemplatesFS, "resources/templates")
 		return defaultTemplatesFS
 	}
-	return os.DirFS(filepath)
+	return overlayFS{fs: os.DirFS(filepath), fallback: defaultTemplatesFS}
+}
+
+// overlayFS file system that uses fallback for files missing on fs, so custom templates dir only needs to define what it overrides.
+type overlayFS struct {
+	fs       fs.FS
+	fallback fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	file, err := o.fs.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.fallback.Open(name)
+	}
+	return file, err
+}
+
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(o.fs, name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	fallbackEntries, ferr := fs.ReadDir(o.fallback, name)
+	if ferr != nil && !errors.Is(ferr, fs.ErrNotExist) {
+		return nil, ferr
+	}
+	if err != nil && ferr != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+	for _, entry := range fallbackEntries {
+		if !names[entry.Name()] {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
 }
 
 func main() {
 	log.SetFlags(0)
 
-	repoPath, rerr := getRepoPath()
-	if rerr != nil {
-		log.Fatal("failed to discovery repository top level, error: ", rerr)
-	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	cfg := loadCfg(repoPath)
-	messageProcessor := sv.NewMessageProcessor(cfg.CommitMessage, cfg.Branches)
-	git := sv.NewGit(messageProcessor, cfg.Tag)
-	semverProcessor := sv.NewSemVerCommitsProcessor(cfg.Versioning, cfg.CommitMessage)
-	releasenotesProcessor := sv.NewReleaseNoteProcessor(cfg.ReleaseNotes)
-	outputFormatter := sv.NewOutputFormatter(templateFS(filepath.Join(repoPath, configDir, "templates")))
+	d := &dependencies{}
+	cancel := context.CancelFunc(func() {})
 
 	app := cli.NewApp()
 	app.Name = "sv"
 	app.Version = Version
 	app.Usage = "semantic version for git"
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: "C", Usage: "run as if sv was started in `path` instead of the current working directory"},
+		&cli.DurationFlag{Name: "timeout", Usage: "abort git commands still running after `duration`, eg.: 30s, 5m"},
+	}
+	app.Before = func(c *cli.Context) error {
+		if timeout := c.Duration("timeout"); timeout > 0 {
+			c.Context, cancel = context.WithTimeout(c.Context, timeout)
+		}
+		return d.load(c.Context, c.String("C"))
+	}
+	app.After = func(c *cli.Context) error {
+		cancel()
+		if err := d.cache.Save(); err != nil {
+			warnf("could not save cache, %s", err.Error())
+		}
+		return nil
+	}
 	app.Commands = []*cli.Command{
 		{
 			Name:    "config",
@@ -66,7 +124,18 @@ func main() {
 				{
 					Name:   "show",
 					Usage:  "show current config",
-					Action: configShowHandler(cfg),
+					Action: action(func() cli.ActionFunc { return configShowHandler(d.cfg) }),
+				},
+			},
+		},
+		{
+			Name:  "cache",
+			Usage: "cache of parsed commits, enabled using 'cache.enabled' config",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "clear",
+					Usage:  "remove cache",
+					Action: action(func() cli.ActionFunc { return cacheClearHandler(d.cacheDir) }),
 				},
 			},
 		},
@@ -74,69 +143,95 @@ func main() {
 			Name:    "current-version",
 			Aliases: []string{"cv"},
 			Usage:   "get last released version from git",
-			Action:  currentVersionHandler(git),
+			Action:  action(func() cli.ActionFunc { return currentVersionHandler(d.git, d.releaser) }),
+			Flags:   []cli.Flag{outputFlag()},
 		},
 		{
 			Name:    "next-version",
 			Aliases: []string{"nv"},
 			Usage:   "generate the next version based on git commit messages",
-			Action:  nextVersionHandler(git, semverProcessor),
+			Action:  action(func() cli.ActionFunc { return nextVersionHandler(d.releaser) }),
+			Flags:   []cli.Flag{outputFlag()},
+		},
+		{
+			Name:    "versions",
+			Aliases: []string{"vs"},
+			Usage:   "list tags recognized as versions sorted by semver",
+			Action:  action(func() cli.ActionFunc { return versionsHandler(d.releaser) }),
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "constraint", Aliases: []string{"c"}, Usage: "only versions matching a semver constraint, eg.: \"^1.2\""},
+				&cli.BoolFlag{Name: "latest-per-major", Usage: "only the highest version of each major"},
+				&cli.BoolFlag{Name: "latest-per-minor", Usage: "only the highest version of each minor"},
+				&cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "output format, use: text or json", Value: outputText},
+			},
 		},
 		{
 			Name:        "commit-log",
 			Aliases:     []string{"cl"},
 			Usage:       "list all commit logs according to range as jsons",
-			Description: "The range filter is used based on git log filters, check https://git-scm.com/docs/git-log for more info. When flag range is \"tag\" and start is empty, last tag created will be used instead. When flag range is \"date\", if \"end\" is YYYY-MM-DD the range will be inclusive.",
-			Action:      commitLogHandler(git),
+			Description: "The range filter is used based on git log filters, check https://git-scm.com/docs/git-log for more info. When flag range is \"tag\" and start is empty, last tag created will be used instead. When flag range is \"date\", if \"end\" is YYYY-MM-DD the range will be inclusive. When flag range is \"version\", start and end versions are included and resolved to tags using tag pattern, start can also be a semver constraint, eg.: \">=1.2 <2\".",
+			Action:      action(func() cli.ActionFunc { return commitLogHandler(d.git, d.releaser) }),
 			Flags: []cli.Flag{
 				&cli.StringFlag{Name: "t", Aliases: []string{"tag"}, Usage: "get commit log from a specific tag"},
-				&cli.StringFlag{Name: "r", Aliases: []string{"range"}, Usage: "type of range of commits, use: tag, date or hash", Value: string(sv.TagRange)},
-				&cli.StringFlag{Name: "s", Aliases: []string{"start"}, Usage: "start range of git log revision range, if date, the value is used on since flag instead"},
-				&cli.StringFlag{Name: "e", Aliases: []string{"end"}, Usage: "end range of git log revision range, if date, the value is used on until flag instead"},
+				&cli.StringFlag{Name: "r", Aliases: []string{"range"}, Usage: "type of range of commits, use: tag, date, hash or version", Value: string(sv.TagRange)},
+				&cli.StringFlag{Name: "s", Aliases: []string{"start", "from"}, Usage: "start range of git log revision range, if date, the value is used on since flag instead, if version, it can be a semver constraint"},
+				&cli.StringFlag{Name: "e", Aliases: []string{"end", "to"}, Usage: "end range of git log revision range, if date, the value is used on until flag instead"},
 			},
 		},
 		{
 			Name:        "commit-notes",
 			Aliases:     []string{"cn"},
 			Usage:       "generate a commit notes according to range",
-			Description: "The range filter is used based on git log filters, check https://git-scm.com/docs/git-log for more info. When flag range is \"tag\" and start is empty, last tag created will be used instead. When flag range is \"date\", if \"end\" is YYYY-MM-DD the range will be inclusive.",
-			Action:      commitNotesHandler(git, releasenotesProcessor, outputFormatter),
+			Description: "The range filter is used based on git log filters, check https://git-scm.com/docs/git-log for more info. When flag range is \"tag\" and start is empty, last tag created will be used instead. When flag range is \"date\", if \"end\" is YYYY-MM-DD the range will be inclusive. When flag range is \"version\", start and end versions are included and resolved to tags using tag pattern, start can also be a semver constraint, eg.: \">=1.2 <2\".",
+			Action:      action(func() cli.ActionFunc { return commitNotesHandler(d.git, d.releaser, d.outputFormatter) }),
 			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "r", Aliases: []string{"range"}, Usage: "type of range of commits, use: tag, date or hash", Required: true},
-				&cli.StringFlag{Name: "s", Aliases: []string{"start"}, Usage: "start range of git log revision range, if date, the value is used on since flag instead"},
-				&cli.StringFlag{Name: "e", Aliases: []string{"end"}, Usage: "end range of git log revision range, if date, the value is used on until flag instead"},
+				&cli.StringFlag{Name: "r", Aliases: []string{"range"}, Usage: "type of range of commits, use: tag, date, hash or version", Required: true},
+				&cli.StringFlag{Name: "s", Aliases: []string{"start", "from"}, Usage: "start range of git log revision range, if date, the value is used on since flag instead, if version, it can be a semver constraint"},
+				&cli.StringFlag{Name: "e", Aliases: []string{"end", "to"}, Usage: "end range of git log revision range, if date, the value is used on until flag instead"},
 			},
 		},
 		{
 			Name:    "release-notes",
 			Aliases: []string{"rn"},
 			Usage:   "generate release notes",
-			Action:  releaseNotesHandler(git, semverProcessor, releasenotesProcessor, outputFormatter),
+			Action:  action(func() cli.ActionFunc { return releaseNotesHandler(d.releaser, d.outputFormatter) }),
 			Flags:   []cli.Flag{&cli.StringFlag{Name: "t", Aliases: []string{"tag"}, Usage: "get release note from tag"}},
 		},
 		{
 			Name:    "changelog",
 			Aliases: []string{"cgl"},
 			Usage:   "generate changelog",
-			Action:  changelogHandler(git, semverProcessor, releasenotesProcessor, outputFormatter),
+			Action:  action(func() cli.ActionFunc { return changelogHandler(d.releaser, d.outputFormatter) }),
 			Flags: []cli.Flag{
 				&cli.IntFlag{Name: "size", Value: 10, Aliases: []string{"n"}, Usage: "get changelog from last 'n' tags"},
 				&cli.BoolFlag{Name: "all", Usage: "ignore size parameter, get changelog for every tag"},
 				&cli.BoolFlag{Name: "add-next-version", Usage: "add next version on change log (commits since last tag, but only if there is a new version to release)"},
 				&cli.BoolFlag{Name: "semantic-version-only", Usage: "only show tags 'SemVer-ish'"},
+				&cli.StringFlag{Name: "from", Usage: "only show versions from this version, included, or matching a semver constraint, eg.: \">=1.2 <2\", ignores size parameter"},
+				&cli.StringFlag{Name: "to", Usage: "only show versions until this version, included, ignores size parameter"},
+			},
+		},
+		{
+			Name:    "upgrade-notes",
+			Aliases: []string{"un"},
+			Usage:   "generate upgrade notes merging release notes of all versions after 'from' until 'to'",
+			Action:  action(func() cli.ActionFunc { return upgradeNotesHandler(d.releaser, d.outputFormatter) }),
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "from", Required: true, Usage: "current version, not included"},
+				&cli.StringFlag{Name: "to", Usage: "target version, included, last version if empty"},
 			},
 		},
 		{
 			Name:    "tag",
 			Aliases: []string{"tg"},
 			Usage:   "generate tag with version based on git commit messages",
-			Action:  tagHandler(git, semverProcessor),
+			Action:  action(func() cli.ActionFunc { return tagHandler(d.git, d.releaser) }),
 		},
 		{
 			Name:    "commit",
 			Aliases: []string{"cmt"},
 			Usage:   "execute git commit with convetional commit message helper",
-			Action:  commitHandler(cfg, git, messageProcessor),
+			Action:  action(func() cli.ActionFunc { return commitHandler(d.cfg, d.git, d.messageProcessor) }),
 			Flags: []cli.Flag{
 				&cli.BoolFlag{Name: "no-scope", Aliases: []string{"nsc"}, Usage: "do not prompt for commit scope"},
 				&cli.BoolFlag{Name: "no-body", Aliases: []string{"nbd"}, Usage: "do not prompt for commit body"},
@@ -152,20 +247,111 @@ func main() {
 			Name:    "validate-commit-message",
 			Aliases: []string{"vcm"},
 			Usage:   "use as prepare-commit-message hook to validate and enhance commit message",
-			Action:  validateCommitMessageHandler(git, messageProcessor),
+			Action:  action(func() cli.ActionFunc { return validateCommitMessageHandler(d.git, d.messageProcessor) }),
 			Flags: []cli.Flag{
 				&cli.StringFlag{Name: "path", Required: true, Usage: "git working directory"},
 				&cli.StringFlag{Name: "file", Required: true, Usage: "name of the file that contains the commit log message"},
 				&cli.StringFlag{Name: "source", Required: true, Usage: "source of the commit message"},
+				&cli.BoolFlag{Name: "fix", Usage: "rewrite commit message file applying suggested corrections before validation"},
+			},
+		},
+		{
+			Name:    "validate",
+			Aliases: []string{"val"},
+			Usage:   "validate commit messages of a range of commits or a single message, merge commits are ignored",
+			Action:  action(func() cli.ActionFunc { return validateHandler(d.validationGit(), d.messageProcessor) }),
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "range", Aliases: []string{"r"}, Usage: "git revision range, eg.: origin/main..HEAD"},
+				&cli.BoolFlag{Name: "since-tag", Usage: "validate commits since last tag"},
+				&cli.StringFlag{Name: "message", Aliases: []string{"m"}, Usage: "validate a message instead of commits, eg.: a pull request title"},
+				&cli.BoolFlag{Name: "stdin", Usage: "validate a message read from standard input"},
+				&cli.StringFlag{Name: "file", Usage: "validate a commit message file, eg.: on commit-msg hook"},
+				&cli.BoolFlag{Name: "fix", Usage: "rewrite commit message file applying suggested corrections, requires file flag"},
+				&cli.StringFlag{Name: "format", Aliases: []string{"f", "output", "o"}, Usage: "output format, use: text, json, junit or sarif", Value: outputText},
 			},
 		},
 	}
 
-	if apperr := app.Run(os.Args); apperr != nil {
+	if apperr := app.RunContext(ctx, os.Args); apperr != nil {
+		stop()
 		log.Fatal("ERROR: ", apperr)
 	}
 }
 
+type dependencies struct {
+	cfg                   Config
+	git                   sv.GitContext
+	messageProcessor      sv.MessageProcessor
+	semverProcessor       sv.SemVerCommitsProcessor
+	releasenotesProcessor sv.ReleaseNoteProcessor
+	releaser              sv.Releaser
+	outputFormatter       sv.OutputFormatter
+	cache                 *sv.Cache
+	cacheDir              string
+	repoPath              string
+}
+
+func (d *dependencies) load(ctx context.Context, path string) error {
+	repoPath, err := getRepoPath(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to discovery repository top level, error: %v", err)
+	}
+
+	d.repoPath = repoPath
+	d.cfg = loadCfg(repoPath)
+	if err := d.cfg.Log.Validate(); err != nil {
+		return fmt.Errorf("invalid config, error: %v", err)
+	}
+	if d.cacheDir, err = getCacheDir(ctx, repoPath); err != nil {
+		return fmt.Errorf("failed to discovery git dir, error: %v", err)
+	}
+	if d.cfg.Cache.Enabled {
+		d.cache = loadCache(d.cacheDir, d.cfg)
+	}
+
+	d.messageProcessor = sv.NewMessageProcessor(d.cfg.CommitMessage, d.cfg.Branches)
+	d.git = sv.NewGit(sv.GitRepository{Path: repoPath}, d.messageProcessor, d.cfg.Tag, d.cfg.Log).WithCache(d.cache)
+	d.semverProcessor = sv.NewSemVerCommitsProcessor(d.cfg.Versioning, d.cfg.CommitMessage)
+	d.releasenotesProcessor = sv.NewReleaseNoteProcessor(d.cfg.ReleaseNotes)
+	d.releaser = sv.NewReleaser(d.git, d.semverProcessor, d.releasenotesProcessor, d.cfg.Tag).WithCache(d.cache)
+	d.outputFormatter = sv.NewOutputFormatter(templateFS(filepath.Join(repoPath, configDir, "templates")))
+	return nil
+}
+
+// validationGit git that keeps non conventional commits on log, so they are reported instead of aborting validation.
+func (d *dependencies) validationGit() sv.GitContext {
+	logCfg := d.cfg.Log
+	logCfg.Lenient = true
+	logCfg.Files = false
+	return sv.NewGit(sv.GitRepository{Path: d.repoPath}, d.messageProcessor, d.cfg.Tag, logCfg)
+}
+
+// loadCache load cache for config, if cache could not be loaded, a warning is printed and cache is not used.
+func loadCache(dir string, cfg Config) *sv.Cache {
+	hash, err := configHash(cfg)
+	if err != nil {
+		warnf("cache disabled, could not hash config, %s", err.Error())
+		return nil
+	}
+	cache, err := sv.NewCache(dir, hash)
+	if err != nil {
+		warnf("cache disabled, %s", err.Error())
+		return nil
+	}
+	return cache
+}
+
+// action creates the command handler only when it is executed, after dependencies are loaded using global flags.
+func action(handler func() cli.ActionFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		return handler()(c)
+	}
+}
+
+func outputFlag() cli.Flag {
+	return &cli.StringFlag{Name: "output", Aliases: []string{"o"}, Usage: "output format, use: text, json, env or github-output", Value: outputText}
+}
+
 func loadCfg(repoPath string) Config {
 	cfg := defaultConfig()
 
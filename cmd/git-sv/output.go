@@ -0,0 +1,394 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/bvieira/sv4git/v2/sv"
+)
+
+const (
+	outputText         = "text"
+	outputJSON         = "json"
+	outputEnv          = "env"
+	outputGithubOutput = "github-output"
+	outputJUnit        = "junit"
+	outputSARIF        = "sarif"
+)
+
+type versionOutput struct {
+	CurrentVersion string `json:"currentVersion"`
+	CurrentTag     string `json:"currentTag"`
+	NextVersion    string `json:"nextVersion"`
+	NextTag        string `json:"nextTag"`
+	Bump           string `json:"bump"`
+	Updated        bool   `json:"updated"`
+	CommitCount    int    `json:"commitCount"`
+	CommitRange    string `json:"commitRange"`
+}
+
+type validationOutput struct {
+	Valid   bool                  `json:"valid"`
+	Results []sv.CommitViolations `json:"results"`
+}
+
+type versionTagOutput struct {
+	Version string `json:"version"`
+	Tag     string `json:"tag"`
+	Date    string `json:"date"`
+	Commit  string `json:"commit"`
+}
+
+func newVersionTagOutput(v sv.VersionTag) versionTagOutput {
+	date := ""
+	if !v.Date.IsZero() {
+		date = v.Date.Format("2006-01-02")
+	}
+	return versionTagOutput{Version: v.Version.String(), Tag: v.Tag, Date: date, Commit: v.Hash}
+}
+
+func newVersionOutput(info sv.VersionInfo) versionOutput {
+	return versionOutput{
+		CurrentVersion: info.Current.String(),
+		CurrentTag:     info.CurrentTag,
+		NextVersion:    info.Next.String(),
+		NextTag:        info.NextTag,
+		Bump:           info.Bump(),
+		Updated:        info.Updated,
+		CommitCount:    len(info.Commits),
+		CommitRange:    info.CommitRange(),
+	}
+}
+
+func printVersionInfo(info sv.VersionInfo, output string) error {
+	content, err := formatVersionInfo(info, output)
+	if err != nil {
+		return err
+	}
+	fmt.Println(content)
+	return nil
+}
+
+func formatVersionInfo(info sv.VersionInfo, output string) (string, error) {
+	out := newVersionOutput(info)
+	switch output {
+	case outputJSON:
+		content, err := json.Marshal(out)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	case outputEnv:
+		return formatKeyValues(versionOutputValues(out), "SV_", strings.ToUpper, "_"), nil
+	case outputGithubOutput:
+		return formatKeyValues(versionOutputValues(out), "", strings.ToLower, "-"), nil
+	default:
+		return "", fmt.Errorf("invalid output: %s, expected: %s, %s, %s or %s", output, outputText, outputJSON, outputEnv, outputGithubOutput)
+	}
+}
+
+func formatVersions(versions []sv.VersionTag, output string) (string, error) {
+	out := make([]versionTagOutput, len(versions))
+	for i, v := range versions {
+		out[i] = newVersionTagOutput(v)
+	}
+
+	switch output {
+	case outputText:
+		lines := make([]string, len(out))
+		for i, v := range out {
+			lines[i] = strings.Join([]string{v.Version, v.Tag, v.Date, shortHash(v.Commit)}, "\t")
+		}
+		return strings.Join(lines, "\n"), nil
+	case outputJSON:
+		content, err := json.Marshal(out)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	default:
+		return "", fmt.Errorf("invalid output: %s, expected: %s or %s", output, outputText, outputJSON)
+	}
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+func versionOutputValues(out versionOutput) [][2]string {
+	return [][2]string{
+		{"current version", out.CurrentVersion},
+		{"current tag", out.CurrentTag},
+		{"next version", out.NextVersion},
+		{"next tag", out.NextTag},
+		{"bump", out.Bump},
+		{"updated", fmt.Sprint(out.Updated)},
+		{"commit count", fmt.Sprint(out.CommitCount)},
+		{"commit range", out.CommitRange},
+	}
+}
+
+func formatKeyValues(values [][2]string, prefix string, keyCase func(string) string, separator string) string {
+	lines := make([]string, len(values))
+	for i, v := range values {
+		lines[i] = fmt.Sprintf("%s%s=%s", prefix, keyCase(strings.ReplaceAll(v[0], " ", separator)), v[1])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatValidation format validation result, text output list violations grouped by commit,
+// eg.: "3f1a4b5 feat: Add thing\n  error: subject-case: description...".
+func formatValidation(commits []sv.CommitViolations, valid bool, output string) (string, error) {
+	if commits == nil {
+		commits = []sv.CommitViolations{}
+	}
+	switch output {
+	case outputText:
+		var lines []string
+		for _, commit := range commits {
+			if len(commit.Violations) == 0 {
+				continue
+			}
+			lines = append(lines, validationName(commit))
+			for _, v := range commit.Violations {
+				lines = append(lines, fmt.Sprintf("  %s: %s: %s", v.Severity, v.Rule, violationText(v)))
+			}
+		}
+		return strings.Join(lines, "\n"), nil
+	case outputJSON:
+		return marshal(json.Marshal(validationOutput{Valid: valid, Results: commits}))
+	case outputJUnit:
+		content, err := marshal(xml.MarshalIndent(newJUnitOutput(commits), "", "  "))
+		return xml.Header + content, err
+	case outputSARIF:
+		return marshal(json.MarshalIndent(newSARIFOutput(commits), "", "  "))
+	default:
+		return "", fmt.Errorf("invalid output: %s, expected: %s, %s, %s or %s", output, outputText, outputJSON, outputJUnit, outputSARIF)
+	}
+}
+
+func marshal(content []byte, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// validationName identify a validated message using short hash and subject.
+func validationName(commit sv.CommitViolations) string {
+	return strings.TrimSpace(shortHash(commit.Hash) + " " + commit.Subject)
+}
+
+// violationText violation message with suggestion, if any.
+func violationText(v sv.RuleViolation) string {
+	if v.Suggestion == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s, suggestion: %s", v.Message, v.Suggestion)
+}
+
+func violationLocation(v sv.RuleViolation) string {
+	if v.Line == 0 {
+		return v.Segment
+	}
+	return fmt.Sprintf("%s %d:%d", v.Segment, v.Line, v.Column)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// newJUnitOutput one test case per validated message, errors are failures and warnings are written on system-out.
+func newJUnitOutput(commits []sv.CommitViolations) junitTestSuites {
+	suite := junitTestSuite{Name: "commit messages", Tests: len(commits), Cases: []junitTestCase{}}
+	for _, commit := range commits {
+		tc := junitTestCase{Name: validationName(commit), ClassName: "git-sv.validate"}
+		var errs, warnings []string
+		for _, v := range commit.Violations {
+			line := fmt.Sprintf("%s (%s): %s", v.Rule, violationLocation(v), violationText(v))
+			if v.Severity == sv.RuleSeverityError {
+				errs = append(errs, line)
+			} else {
+				warnings = append(warnings, line)
+			}
+		}
+		if len(errs) > 0 {
+			tc.Failure = &junitFailure{Message: fmt.Sprintf("%d rule violation(s)", len(errs)), Type: "rule-violation", Text: strings.Join(errs, "\n")}
+			suite.Failures++
+		}
+		tc.SystemOut = strings.Join(warnings, "\n")
+		suite.Cases = append(suite.Cases, tc)
+	}
+	return junitTestSuites{Tests: suite.Tests, Failures: suite.Failures, Suites: []junitTestSuite{suite}}
+}
+
+type sarifOutput struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID     string                `json:"ruleId"`
+	Level      string                `json:"level"`
+	Message    sarifMessage          `json:"message"`
+	Locations  []sarifLocation       `json:"locations"`
+	Properties sarifResultProperties `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+type sarifResultProperties struct {
+	Subject    string `json:"subject"`
+	Segment    string `json:"segment"`
+	Line       int    `json:"line,omitempty"`
+	Column     int    `json:"column,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// sarifMessageURI artifact used as physical location of results, code scanning tools only show results with a physical location.
+const sarifMessageURI = "COMMIT_EDITMSG"
+
+// sarifRuleDescriptions short description of each rule, same as rules documentation.
+var sarifRuleDescriptions = map[string]string{
+	sv.RuleHeaderFormat:        "header should match type(scope)!: description",
+	sv.RuleTypeEnum:            "type should be one of commit-message.types",
+	sv.RuleScopeEnum:           "scope should be one of commit-message.scope.values",
+	sv.RuleSubjectCase:         "first letter of description should match case",
+	sv.RuleSubjectFullStop:     "description should not end with a period",
+	sv.RuleHeaderMaxLength:     "max length of the header",
+	sv.RuleScopeRequired:       "scope should be defined",
+	sv.RuleScopeCase:           "each scope should match case",
+	sv.RuleBodyLeadingBlank:    "body should begin with a blank line",
+	sv.RuleBodyMaxLineLength:   "max length of each body line",
+	sv.RuleFooterMaxLineLength: "max length of each footer line",
+	sv.RuleBodyRequired:        "body should be defined",
+	sv.RuleFooterRequired:      "footers should be defined",
+	sv.RuleForbiddenWords:      "message should not contain forbidden words",
+}
+
+// newSARIFOutput SARIF 2.1.0 log, results use commit message as physical location and commit hash as logical location.
+func newSARIFOutput(commits []sv.CommitViolations) sarifOutput {
+	results := []sarifResult{}
+	var rules []sarifRule
+	for _, commit := range commits {
+		for _, v := range commit.Violations {
+			results = append(results, sarifResult{
+				RuleID:  v.Rule,
+				Level:   string(v.Severity),
+				Message: sarifMessage{Text: v.Message},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifMessageURI}, Region: sarifRegion{StartLine: sarifLine(v.Line), StartColumn: v.Column}},
+					LogicalLocations: []sarifLogicalLocation{{Name: str(shortHash(commit.Hash), "message"), FullyQualifiedName: commit.Hash, Kind: "object"}},
+				}},
+				Properties: sarifResultProperties{Subject: commit.Subject, Segment: v.Segment, Line: v.Line, Column: v.Column, Suggestion: v.Suggestion},
+			})
+			if !containsRule(rules, v.Rule) {
+				rules = append(rules, sarifRule{ID: v.Rule, ShortDescription: sarifMessage{Text: str(sarifRuleDescriptions[v.Rule], v.Rule)}})
+			}
+		}
+	}
+	if rules == nil {
+		rules = []sarifRule{}
+	}
+
+	driver := sarifDriver{Name: "git-sv", Version: Version, InformationURI: "https://github.com/bvieira/sv4git", Rules: rules}
+	return sarifOutput{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
+
+// sarifLine region start line, first line if violation is about something missing.
+func sarifLine(line int) int {
+	if line < 1 {
+		return 1
+	}
+	return line
+}
+
+func containsRule(rules []sarifRule, id string) bool {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bvieira/sv4git/v2/sv"
+)
+
+var versionInfoSample = sv.VersionInfo{
+	Current:    semver.MustParse("1.2.3-rc.1"),
+	CurrentTag: "v1.2.3-rc.1",
+	Next:       semver.MustParse("1.3.0"),
+	NextTag:    "v1.3.0",
+	Updated:    true,
+	Commits:    make([]sv.GitCommitLog, 2),
+}
+
+var versionInfoEnv = `SV_CURRENT_VERSION=1.2.3-rc.1
+SV_CURRENT_TAG=v1.2.3-rc.1
+SV_NEXT_VERSION=1.3.0
+SV_NEXT_TAG=v1.3.0
+SV_BUMP=minor
+SV_UPDATED=true
+SV_COMMIT_COUNT=2
+SV_COMMIT_RANGE=v1.2.3-rc.1..HEAD`
+
+var versionInfoGithubOutput = `current-version=1.2.3-rc.1
+current-tag=v1.2.3-rc.1
+next-version=1.3.0
+next-tag=v1.3.0
+bump=minor
+updated=true
+commit-count=2
+commit-range=v1.2.3-rc.1..HEAD`
+
+func Test_formatVersionInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    sv.VersionInfo
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{"json", versionInfoSample, outputJSON, `{"currentVersion":"1.2.3-rc.1","currentTag":"v1.2.3-rc.1","nextVersion":"1.3.0","nextTag":"v1.3.0","bump":"minor","updated":true,"commitCount":2,"commitRange":"v1.2.3-rc.1..HEAD"}`, false},
+		{"env", versionInfoSample, outputEnv, versionInfoEnv, false},
+		{"github output", versionInfoSample, outputGithubOutput, versionInfoGithubOutput, false},
+		{"invalid output", versionInfoSample, "xml", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatVersionInfo(tt.info, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatVersionInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("formatVersionInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var versionsSample = []sv.VersionTag{
+	{Version: semver.MustParse("1.0.0"), Tag: "v1.0.0", Date: time.Date(2020, 5, 1, 18, 0, 0, 0, time.UTC), Hash: "3f1a4b5c6d7e8f90"},
+	{Version: semver.MustParse("1.1.0"), Tag: "v1.1.0", Hash: "a1b2c3d"},
+}
+
+func Test_formatVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []sv.VersionTag
+		output   string
+		want     string
+		wantErr  bool
+	}{
+		{"text", versionsSample, outputText, "1.0.0\tv1.0.0\t2020-05-01\t3f1a4b5\n1.1.0\tv1.1.0\t\ta1b2c3d", false},
+		{"json", versionsSample, outputJSON, `[{"version":"1.0.0","tag":"v1.0.0","date":"2020-05-01","commit":"3f1a4b5c6d7e8f90"},{"version":"1.1.0","tag":"v1.1.0","date":"","commit":"a1b2c3d"}]`, false},
+		{"empty json", nil, outputJSON, "[]", false},
+		{"invalid output", versionsSample, outputEnv, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatVersions(tt.versions, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatVersions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("formatVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var validationSample = []sv.CommitViolations{
+	{Hash: "3f1a4b5c6d7e8f90", Subject: "Fix something", Violations: []sv.RuleViolation{{Rule: sv.RuleHeaderFormat, Severity: sv.RuleSeverityError, Message: "invalid header", Segment: sv.SegmentHeader, Line: 1, Column: 1}}},
+	{Subject: "feat: Add thing", Violations: []sv.RuleViolation{{Rule: sv.RuleSubjectCase, Severity: sv.RuleSeverityWarning, Message: "invalid case", Segment: sv.SegmentDescription, Line: 1, Column: 7, Suggestion: "add thing"}}},
+	{Hash: "b2c3d4e", Subject: "fix: valid"},
+}
+
+var validationJUnit = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1">
+  <testsuite name="commit messages" tests="3" failures="1">
+    <testcase name="3f1a4b5 Fix something" classname="git-sv.validate">
+      <failure message="1 rule violation(s)" type="rule-violation">header-format (header 1:1): invalid header</failure>
+    </testcase>
+    <testcase name="feat: Add thing" classname="git-sv.validate">
+      <system-out>subject-case (description 1:7): invalid case, suggestion: add thing</system-out>
+    </testcase>
+    <testcase name="b2c3d4e fix: valid" classname="git-sv.validate"></testcase>
+  </testsuite>
+</testsuites>`
+
+func Test_formatValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		commits []sv.CommitViolations
+		valid   bool
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{"empty text", nil, true, outputText, "", false},
+		{"text", validationSample, false, outputText, "3f1a4b5 Fix something\n  error: header-format: invalid header\nfeat: Add thing\n  warning: subject-case: invalid case, suggestion: add thing", false},
+		{"empty json", nil, true, outputJSON, `{"valid":true,"results":[]}`, false},
+		{"json", validationSample, false, outputJSON, `{"valid":false,"results":[{"hash":"3f1a4b5c6d7e8f90","subject":"Fix something","violations":[{"rule":"header-format","severity":"error","message":"invalid header","segment":"header","line":1,"column":1}]},{"subject":"feat: Add thing","violations":[{"rule":"subject-case","severity":"warning","message":"invalid case","segment":"description","line":1,"column":7,"suggestion":"add thing"}]},{"hash":"b2c3d4e","subject":"fix: valid"}]}`, false},
+		{"junit", validationSample, false, outputJUnit, validationJUnit, false},
+		{"invalid output", validationSample, false, outputEnv, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatValidation(tt.commits, tt.valid, tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("formatValidation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("formatValidation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newSARIFOutput(t *testing.T) {
+	got := newSARIFOutput(validationSample)
+	if len(got.Runs) != 1 {
+		t.Fatalf("newSARIFOutput() runs = %d, want 1", len(got.Runs))
+	}
+	wantRules := []sarifRule{
+		{ID: sv.RuleHeaderFormat, ShortDescription: sarifMessage{Text: "header should match type(scope)!: description"}},
+		{ID: sv.RuleSubjectCase, ShortDescription: sarifMessage{Text: "first letter of description should match case"}},
+	}
+	if !reflect.DeepEqual(got.Runs[0].Tool.Driver.Rules, wantRules) {
+		t.Errorf("newSARIFOutput() rules = %v, want %v", got.Runs[0].Tool.Driver.Rules, wantRules)
+	}
+	wantResults := []sarifResult{
+		{
+			RuleID:  sv.RuleHeaderFormat,
+			Level:   "error",
+			Message: sarifMessage{Text: "invalid header"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "COMMIT_EDITMSG"}, Region: sarifRegion{StartLine: 1, StartColumn: 1}},
+				LogicalLocations: []sarifLogicalLocation{{Name: "3f1a4b5", FullyQualifiedName: "3f1a4b5c6d7e8f90", Kind: "object"}},
+			}},
+			Properties: sarifResultProperties{Subject: "Fix something", Segment: sv.SegmentHeader, Line: 1, Column: 1},
+		},
+		{
+			RuleID:  sv.RuleSubjectCase,
+			Level:   "warning",
+			Message: sarifMessage{Text: "invalid case"},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: "COMMIT_EDITMSG"}, Region: sarifRegion{StartLine: 1, StartColumn: 7}},
+				LogicalLocations: []sarifLogicalLocation{{Name: "message", Kind: "object"}},
+			}},
+			Properties: sarifResultProperties{Subject: "feat: Add thing", Segment: sv.SegmentDescription, Line: 1, Column: 7, Suggestion: "add thing"},
+		},
+	}
+	if !reflect.DeepEqual(got.Runs[0].Results, wantResults) {
+		t.Errorf("newSARIFOutput() results = %+v, want %+v", got.Runs[0].Results, wantResults)
+	}
+}
+
+func Test_sarifLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line int
+		want int
+	}{
+		{"missing", 0, 1},
+		{"first line", 1, 1},
+		{"body line", 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sarifLine(tt.line); got != tt.want {
+				t.Errorf("sarifLine() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
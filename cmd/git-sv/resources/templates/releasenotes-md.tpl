@@ -4,5 +4,7 @@
 {{- template "rn-md-section-commits.tpl" $section }}
 {{- else if (eq $section.SectionType "breaking-changes")}}
 {{- template "rn-md-section-breaking-changes.tpl" $section }}
+{{- else if (eq $section.SectionType "non-conventional")}}
+{{- template "rn-md-section-non-conventional.tpl" $section }}
 {{- end}}
 {{- end}}
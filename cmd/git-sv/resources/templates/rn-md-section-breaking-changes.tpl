@@ -2,6 +2,6 @@
 
 ### {{.Name}}
 {{range $k,$v := .Messages}}
-- {{$v}}
+- {{indent 2 $v}}
 {{- end}}
 {{- end}}
\ No newline at end of file
@@ -0,0 +1,7 @@
+{{- if .}}{{- if ne .SectionName ""}}
+
+### {{.SectionName}}
+{{range $k,$v := .Items}}
+- {{$v.Message.Description}} ({{$v.Hash}})
+{{- end}}
+{{- end}}{{- end}}
\ No newline at end of file
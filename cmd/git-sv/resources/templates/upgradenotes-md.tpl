@@ -0,0 +1,15 @@
+## Upgrade {{if .From}}from {{.From}} {{end}}to {{.To}}{{if not .Date.IsZero}} ({{timefmt .Date "2006-01-02"}}){{end}}
+{{- if .BreakingChanges}}
+
+### {{.BreakingChangesName}}
+{{range $k,$v := .BreakingChanges}}
+- **{{$v.Release}}:** {{indent 2 $v.Message}}
+{{- end}}
+{{- end}}
+{{- range $section := .Sections }}
+{{- if (eq $section.SectionType "commits") }}
+{{- template "rn-md-section-commits.tpl" $section }}
+{{- else if (eq $section.SectionType "non-conventional")}}
+{{- template "rn-md-section-non-conventional.tpl" $section }}
+{{- end}}
+{{- end}}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/bvieira/sv4git/v2/sv"
 )
 
 func Test_checkTemplatesFiles(t *testing.T) {
@@ -22,3 +26,19 @@ func Test_checkTemplatesFiles(t *testing.T) {
 		})
 	}
 }
+
+func Test_templateFSFallback(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "releasenotes-md.tpl"), []byte("custom {{.Release}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	formatter := sv.NewOutputFormatter(templateFS(dir))
+
+	got, err := formatter.FormatReleaseNote(sv.ReleaseNote{Tag: "v1.0.0"})
+	if err != nil || got != "custom v1.0.0" {
+		t.Errorf("FormatReleaseNote() = %q, %v, want custom template", got, err)
+	}
+	if _, err := formatter.FormatUpgradeNote(sv.UpgradeNote{}); err != nil {
+		t.Errorf("FormatUpgradeNote() error = %v, want default template", err)
+	}
+}
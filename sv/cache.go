@@ -0,0 +1,196 @@
+package sv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	cacheFormatVersion = "9"
+	cacheFileName      = "cache.json"
+	cacheMaxAge        = 30 * 24 * time.Hour // Entries not used for this long are dropped on save, eg.: rewritten commits or deleted tags.
+	cacheUsedPrecision = 24 * time.Hour      // Last use of an entry is only refreshed after this, so reading cache does not rewrite it on every run.
+)
+
+// Cache on-disk cache of parsed commit messages and commits of each tag.
+// Release notes are built from cached tag commits, so the same entry is used by release notes, changelog and upgrade notes.
+// Entries are only valid for the config used to create them, cache is discarded when config hash changes.
+// A nil Cache is valid and never finds an entry.
+type Cache struct {
+	dir     string
+	data    cacheData
+	changed bool
+	now     func() time.Time
+}
+
+type cacheData struct {
+	Version    string                  `json:"version"`
+	ConfigHash string                  `json:"configHash"`
+	Messages   map[string]cacheMessage `json:"messages"`
+	Tags       map[string]cacheTag     `json:"tags"`
+}
+
+type cacheMessage struct {
+	Message CommitMessage `json:"message"`
+	Used    time.Time     `json:"used"`
+}
+
+type cacheTag struct {
+	Date        time.Time      `json:"date"`
+	PreviousTag string         `json:"previousTag"`
+	Commits     []GitCommitLog `json:"commits"`
+	Used        time.Time      `json:"used"`
+}
+
+// NewCache load cache from dir, if cache does not exist or was created using another config, an empty cache is returned.
+func NewCache(dir, configHash string) (*Cache, error) {
+	c := &Cache{dir: dir, data: cacheData{Version: cacheFormatVersion, ConfigHash: configHash}, now: time.Now}
+
+	content, err := os.ReadFile(filepath.Join(dir, cacheFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read cache, error: %v", err)
+	}
+
+	var data cacheData
+	if err := json.Unmarshal(content, &data); err != nil || data.Version != cacheFormatVersion || data.ConfigHash != configHash {
+		c.changed = true // invalid or outdated, overwrite on save
+		return c, nil
+	}
+	c.data = data
+	return c, nil
+}
+
+// ClearCache remove cache from dir.
+func ClearCache(dir string) error {
+	return os.RemoveAll(dir)
+}
+
+// Message get parsed commit message by commit full hash.
+func (c *Cache) Message(hash string) (CommitMessage, bool) {
+	if c == nil || hash == "" {
+		return CommitMessage{}, false
+	}
+	entry, found := c.data.Messages[hash]
+	if !found {
+		return CommitMessage{}, false
+	}
+	if c.refresh(&entry.Used) {
+		c.data.Messages[hash] = entry
+	}
+	if entry.Message.Metadata == nil { // empty metadata is omitted on json
+		entry.Message.Metadata = make(map[string]string)
+	}
+	return entry.Message, true
+}
+
+// SetMessage store parsed commit message using commit full hash.
+func (c *Cache) SetMessage(hash string, msg CommitMessage) {
+	if c == nil || hash == "" {
+		return
+	}
+	if c.data.Messages == nil {
+		c.data.Messages = make(map[string]cacheMessage)
+	}
+	c.data.Messages[hash] = cacheMessage{Message: msg, Used: c.now()}
+	c.changed = true
+}
+
+// TagCommits get commits between previous tag and tag, entry is ignored if tag was recreated or previous tag changed.
+func (c *Cache) TagCommits(tag GitTag, previousTag string) ([]GitCommitLog, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, found := c.data.Tags[tag.Name]
+	if !found || !entry.Date.Equal(tag.Date) || entry.PreviousTag != previousTag {
+		return nil, false
+	}
+	if c.refresh(&entry.Used) {
+		c.data.Tags[tag.Name] = entry
+	}
+	for i := range entry.Commits {
+		if entry.Commits[i].Message.Metadata == nil { // empty metadata is omitted on json
+			entry.Commits[i].Message.Metadata = make(map[string]string)
+		}
+	}
+	return entry.Commits, true
+}
+
+// SetTagCommits store commits between previous tag and tag.
+func (c *Cache) SetTagCommits(tag GitTag, previousTag string, commits []GitCommitLog) {
+	if c == nil {
+		return
+	}
+	if c.data.Tags == nil {
+		c.data.Tags = make(map[string]cacheTag)
+	}
+	c.data.Tags[tag.Name] = cacheTag{Date: tag.Date, PreviousTag: previousTag, Commits: commits, Used: c.now()}
+	c.changed = true
+}
+
+// refresh update last use of an entry, return true if it was updated.
+func (c *Cache) refresh(used *time.Time) bool {
+	now := c.now()
+	if now.Sub(*used) < cacheUsedPrecision {
+		return false
+	}
+	*used = now
+	c.changed = true
+	return true
+}
+
+// prune remove entries not used for cacheMaxAge.
+func (c *Cache) prune() {
+	limit := c.now().Add(-cacheMaxAge)
+	for hash, entry := range c.data.Messages {
+		if entry.Used.Before(limit) {
+			delete(c.data.Messages, hash)
+		}
+	}
+	for name, entry := range c.data.Tags {
+		if entry.Used.Before(limit) {
+			delete(c.data.Tags, name)
+		}
+	}
+}
+
+// Save write cache to disk if it was changed, entries not used recently are dropped.
+func (c *Cache) Save() error {
+	if c == nil || !c.changed {
+		return nil
+	}
+	c.prune()
+
+	content, err := json.Marshal(c.data)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("could not create cache dir, error: %v", err)
+	}
+
+	// write on a temporary file first, so a concurrent run never reads a partial cache.
+	tmp, err := os.CreateTemp(c.dir, cacheFileName+".*")
+	if err != nil {
+		return fmt.Errorf("could not write cache, error: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write cache, error: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write cache, error: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(c.dir, cacheFileName)); err != nil {
+		return fmt.Errorf("could not write cache, error: %v", err)
+	}
+	c.changed = false
+	return nil
+}
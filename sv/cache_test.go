@@ -0,0 +1,115 @@
+package sv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sv4git")
+	msg := CommitMessage{Type: "feat", Description: "something", Metadata: map[string]string{}}
+	tag := GitTag{Name: "v1.0.0", Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	commits := []GitCommitLog{{Hash: "a1", FullHash: "a1000000", Message: msg}}
+
+	cache, err := NewCache(dir, "config")
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	if _, found := cache.Message("a1000000"); found {
+		t.Errorf("Cache.Message() found on empty cache")
+	}
+	cache.SetMessage("a1000000", msg)
+	cache.SetTagCommits(tag, "", commits)
+	if err := cache.Save(); err != nil {
+		t.Fatalf("Cache.Save() error = %v", err)
+	}
+
+	loaded, err := NewCache(dir, "config")
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	if got, found := loaded.Message("a1000000"); !found || !reflect.DeepEqual(got, msg) {
+		t.Errorf("Cache.Message() = %+v, %v, want %+v", got, found, msg)
+	}
+	if got, found := loaded.TagCommits(tag, ""); !found || !reflect.DeepEqual(got, commits) {
+		t.Errorf("Cache.TagCommits() = %+v, %v, want %+v", got, found, commits)
+	}
+	if _, found := loaded.TagCommits(GitTag{Name: tag.Name, Date: tag.Date.Add(time.Hour)}, ""); found {
+		t.Errorf("Cache.TagCommits() found recreated tag")
+	}
+	if _, found := loaded.TagCommits(tag, "v0.1.0"); found {
+		t.Errorf("Cache.TagCommits() found tag with another previous tag")
+	}
+
+	outdated, err := NewCache(dir, "other config")
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+	if _, found := outdated.Message("a1000000"); found {
+		t.Errorf("Cache.Message() found using another config")
+	}
+
+	if err := ClearCache(dir); err != nil {
+		t.Fatalf("ClearCache() error = %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("ClearCache() dir still exists, error = %v", err)
+	}
+}
+
+func TestCache_nil(t *testing.T) {
+	var cache *Cache
+	cache.SetMessage("a1000000", CommitMessage{})
+	if _, found := cache.Message("a1000000"); found {
+		t.Errorf("Cache.Message() found on nil cache")
+	}
+	if err := cache.Save(); err != nil {
+		t.Errorf("Cache.Save() error = %v", err)
+	}
+}
+
+func TestCache_prune(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "sv4git")
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	load := func(days int) *Cache {
+		cache, err := NewCache(dir, "config")
+		if err != nil {
+			t.Fatalf("NewCache() error = %v", err)
+		}
+		cache.now = func() time.Time { return now.AddDate(0, 0, days) }
+		return cache
+	}
+	save := func(cache *Cache) {
+		if err := cache.Save(); err != nil {
+			t.Fatalf("Cache.Save() error = %v", err)
+		}
+	}
+	tag := GitTag{Name: "v1.0.0", Date: now}
+
+	cache := load(0)
+	cache.SetMessage("a1", CommitMessage{Description: "used"})
+	cache.SetMessage("b2", CommitMessage{Description: "stale"})
+	cache.SetTagCommits(tag, "", nil)
+	save(cache)
+
+	cache = load(20)
+	cache.Message("a1")
+	save(cache)
+
+	cache = load(40)
+	cache.SetMessage("c3", CommitMessage{Description: "new"})
+	save(cache)
+
+	cache = load(40)
+	for hash, want := range map[string]bool{"a1": true, "b2": false, "c3": true} {
+		if _, found := cache.Message(hash); found != want {
+			t.Errorf("Cache.Message(%s) found = %v, want %v", hash, found, want)
+		}
+	}
+	if _, found := cache.TagCommits(tag, ""); found {
+		t.Errorf("Cache.TagCommits() found stale tag")
+	}
+}
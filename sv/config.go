@@ -1,14 +1,25 @@
 package sv
 
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Masterminds/semver/v3"
+)
+
 // ==== Message ====
 
 // CommitMessageConfig config a commit message.
 type CommitMessageConfig struct {
-	Types          []string                             `yaml:"types,flow"`
-	HeaderSelector string                               `yaml:"header-selector"`
-	Scope          CommitMessageScopeConfig             `yaml:"scope"`
-	Footer         map[string]CommitMessageFooterConfig `yaml:"footer"`
-	Issue          CommitMessageIssueConfig             `yaml:"issue"`
+	Types                []string                             `yaml:"types,flow"`
+	TypePattern          string                               `yaml:"type-pattern"`           // Regex for types on header, "[a-z]+" if empty.
+	TypeAliases          map[string]string                    `yaml:"type-aliases"`           // Types replaced on parse, eg.: "feature: feat".
+	CaseInsensitiveTypes bool                                 `yaml:"case-insensitive-types"` // Accept types in any case, types are lower cased on parse.
+	HeaderSelector       string                               `yaml:"header-selector"`
+	Scope                CommitMessageScopeConfig             `yaml:"scope"`
+	Footer               map[string]CommitMessageFooterConfig `yaml:"footer"`
+	Issue                CommitMessageIssueConfig             `yaml:"issue"`
+	Rules                map[string]CommitMessageRuleConfig   `yaml:"rules"`
 }
 
 // IssueFooterConfig config for issue.
@@ -19,9 +30,23 @@ func (c CommitMessageConfig) IssueFooterConfig() CommitMessageFooterConfig {
 	return CommitMessageFooterConfig{}
 }
 
+// CommitMessageRuleConfig config a commit message rule, options are used according with rule.
+type CommitMessageRuleConfig struct {
+	Severity RuleSeverity `yaml:"severity"`               // error, warning or off, error if empty.
+	Length   int          `yaml:"length,omitempty"`       // Max length for *-max-length rules.
+	Case     string       `yaml:"case,omitempty"`         // Case for *-case rules.
+	Types    []string     `yaml:"types,flow,omitempty"`   // Commit types to apply rule, all types if empty.
+	Footers  []string     `yaml:"footers,flow,omitempty"` // Footer keys or tokens for footer-required rule.
+	Words    []string     `yaml:"words,flow,omitempty"`   // Words for forbidden-words rule.
+
+	wordRegexes []*regexp.Regexp // Words compiled by compileRules.
+}
+
 // CommitMessageScopeConfig config scope preferences.
 type CommitMessageScopeConfig struct {
-	Values []string `yaml:"values"`
+	Values    []string `yaml:"values"`
+	Pattern   string   `yaml:"pattern"`   // Regex for scope on header, ".+" if empty.
+	Separator string   `yaml:"separator"` // Split scope in multiple scopes, eg.: "," for "feat(api,ui): ...".
 }
 
 // CommitMessageFooterConfig config footer metadata.
@@ -30,6 +55,7 @@ type CommitMessageFooterConfig struct {
 	KeySynonyms    []string `yaml:"key-synonyms,flow"`
 	UseHash        bool     `yaml:"use-hash"`
 	AddValuePrefix string   `yaml:"add-value-prefix"`
+	ValueSeparator string   `yaml:"value-separator"` // Split footer value in multiple values, eg.: "," for "jira: A-1, A-2".
 }
 
 // CommitMessageIssueConfig issue preferences.
@@ -66,6 +92,60 @@ type TagConfig struct {
 	Filter  *string `yaml:"filter"`
 }
 
+// TagName format version as tag name using configured pattern.
+func (c TagConfig) TagName(version semver.Version) string {
+	return fmt.Sprintf(*c.Pattern, version.Major(), version.Minor(), version.Patch())
+}
+
+// TagVersion parse version from tag name using configured pattern, return false if tag does not match pattern.
+func (c TagConfig) TagVersion(tag string) (*semver.Version, bool) {
+	var major, minor, patch uint64
+	if _, err := fmt.Sscanf(tag, *c.Pattern, &major, &minor, &patch); err != nil {
+		return nil, false
+	}
+	version := semver.New(major, minor, patch, "", "")
+	if c.TagName(*version) != tag {
+		return nil, false
+	}
+	return version, true
+}
+
+// ==== Cache ====
+
+// CacheConfig cache preferences.
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// ==== Log ====
+
+// LogConfig git log preferences.
+type LogConfig struct {
+	Files       bool   `yaml:"files"`
+	Lenient     bool   `yaml:"lenient"`
+	FirstParent bool   `yaml:"first-parent"`
+	Merges      string `yaml:"merges"`
+	MergeBody   bool   `yaml:"merge-body"`
+}
+
+const (
+	// LogMergesInclude LogConfig.Merges value, merge commits are listed with other commits.
+	LogMergesInclude = "include"
+	// LogMergesSkip LogConfig.Merges value, merge commits are ignored.
+	LogMergesSkip = "skip"
+	// LogMergesOnly LogConfig.Merges value, only merge commits are listed.
+	LogMergesOnly = "only"
+)
+
+// Validate check if log config values are valid.
+func (c LogConfig) Validate() error {
+	switch c.Merges {
+	case "", LogMergesInclude, LogMergesSkip, LogMergesOnly:
+		return nil
+	}
+	return fmt.Errorf("invalid log merges: %s, expected: %s, %s or %s", c.Merges, LogMergesInclude, LogMergesSkip, LogMergesOnly)
+}
+
 // ==== Release Notes ====
 
 // ReleaseNotesConfig release notes preferences.
@@ -95,4 +175,6 @@ const (
 	ReleaseNotesSectionTypeCommits = "commits"
 	// ReleaseNotesSectionTypeBreakingChanges ReleaseNotesSectionConfig.SectionType value.
 	ReleaseNotesSectionTypeBreakingChanges = "breaking-changes"
+	// ReleaseNotesSectionTypeNonConventional ReleaseNotesSectionConfig.SectionType value.
+	ReleaseNotesSectionTypeNonConventional = "non-conventional"
 )
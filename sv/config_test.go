@@ -0,0 +1,54 @@
+package sv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+func TestTagConfig_TagVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		tag     string
+		want    *semver.Version
+		wantOk  bool
+	}{
+		{"default pattern", "%d.%d.%d", "1.2.3", version("1.2.3"), true},
+		{"prefix pattern", "v%d.%d.%d", "v1.2.3", version("1.2.3"), true},
+		{"missing prefix", "v%d.%d.%d", "1.2.3", nil, false},
+		{"suffix on tag", "v%d.%d.%d", "v1.2.3-rc.1", nil, false},
+		{"not a version", "%d.%d.%d", "latest", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := tt.pattern
+			got, ok := TagConfig{Pattern: &pattern}.TagVersion(tt.tag)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TagConfig.TagVersion() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestLogConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		merges  string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"include", LogMergesInclude, false},
+		{"skip", LogMergesSkip, false},
+		{"only", LogMergesOnly, false},
+		{"invalid", "none", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (LogConfig{Merges: tt.merges}).Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("LogConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
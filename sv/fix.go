@@ -0,0 +1,147 @@
+package sv
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Fix return commit message with suggested corrections applied to header, body and footers are kept.
+// Only corrections of enabled rules are applied: type typos, type(scope): spacing, description case and trailing period.
+func (p MessageProcessorImpl) Fix(message string) (string, error) {
+	if p.rulesErr != nil {
+		return "", p.rulesErr
+	}
+	if p.grammarErr != nil {
+		return "", p.grammarErr
+	}
+	subject, rest, _ := strings.Cut(message, "\n")
+	fixed, ok := p.fixSubject(subject)
+	if !ok || fixed == subject {
+		return message, nil
+	}
+	if !strings.Contains(message, "\n") {
+		return fixed, nil
+	}
+	return fixed + "\n" + rest, nil
+}
+
+// fixSubject apply corrections on the selected header of a subject, return false if header could not be parsed.
+func (p MessageProcessorImpl) fixSubject(subject string) (string, bool) {
+	header, err := p.prepareHeader(subject)
+	if err != nil {
+		return "", false
+	}
+	match := p.grammar.fix.FindStringSubmatch(header)
+	if match == nil {
+		return "", false
+	}
+	group := func(name string) string { return match[p.grammar.fix.SubexpIndex(name)] }
+
+	msg := CommitMessage{Type: p.normalizeType(strings.ToLower(group(typeGroupName))), Scope: group(scopeGroupName), Description: group(descriptionGroupName), IsBreakingChange: group(breakingGroupName) == "!"}
+	if p.ruleEnabled(RuleTypeEnum, msg.Type) && p.validateType(msg.Type) != nil {
+		if ctype, found := p.suggestType(msg.Type); found {
+			msg.Type = ctype
+		}
+	}
+	if p.ruleEnabled(RuleSubjectFullStop, msg.Type) {
+		msg.Description = strings.TrimRight(msg.Description, ".")
+	}
+	if p.ruleEnabled(RuleSubjectCase, msg.Type) {
+		msg.Description = fixCase(p.rules[RuleSubjectCase].Case, msg.Description)
+	}
+
+	fixed, _, _ := p.Format(msg)
+	return strings.Replace(subject, header, fixed, 1), true
+}
+
+func (p MessageProcessorImpl) ruleEnabled(id, ctype string) bool {
+	cfg := p.rules[id]
+	return cfg.Severity != RuleSeverityOff && (len(cfg.Types) == 0 || contains(ctype, cfg.Types))
+}
+
+// suggestType find the nearest configured type using edit distance, eg.: "feature" to "feat".
+// Types are suggested only if distance is lower than half of type length, or 2 for short types.
+func (p MessageProcessorImpl) suggestType(ctype string) (string, bool) {
+	ctype = strings.ToLower(ctype)
+	limit := utf8.RuneCountInString(ctype) / 2
+	if limit < 2 {
+		limit = 2
+	}
+
+	suggestion, best := "", limit+1
+	for _, t := range p.messageCfg.Types {
+		if distance := editDistance(ctype, t); distance < best {
+			suggestion, best = t, distance
+		}
+	}
+	return suggestion, suggestion != ""
+}
+
+// fixCase change description first letter to match subject-case rule.
+func fixCase(descriptionCase, description string) string {
+	first, size := utf8.DecodeRuneInString(description)
+	if first == utf8.RuneError {
+		return description
+	}
+	if descriptionCase == CaseSentence {
+		return string(unicode.ToUpper(first)) + description[size:]
+	}
+	if word, _, _ := strings.Cut(description, " "); utf8.RuneCountInString(word) > 1 && strings.ToUpper(word) == word {
+		return description // keep acronyms, eg.: "API"
+	}
+	return string(unicode.ToLower(first)) + description[size:]
+}
+
+// editDistance levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current := make([]int, len(rb)+1)
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(rb)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
+// suggestion replacement for violation segment, empty if there is no suggestion.
+func (p MessageProcessorImpl) suggestion(v RuleViolation, m lintMessage) string {
+	switch v.Rule {
+	case RuleHeaderFormat:
+		if fixed, ok := p.fixSubject(m.header); ok && fixed != m.header {
+			return fixed
+		}
+	case RuleTypeEnum:
+		if ctype, found := p.suggestType(m.msg.Type); found {
+			return ctype
+		}
+	case RuleSubjectCase:
+		if fixed := fixCase(p.rules[RuleSubjectCase].Case, m.msg.Description); fixed != m.msg.Description {
+			return fixed
+		}
+	case RuleSubjectFullStop:
+		return strings.TrimRight(m.msg.Description, ".")
+	}
+	return ""
+}
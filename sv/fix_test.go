@@ -0,0 +1,113 @@
+package sv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMessageProcessorImpl_Fix(t *testing.T) {
+	fullStop := map[string]CommitMessageRuleConfig{RuleSubjectFullStop: {}}
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		message string
+		want    string
+		wantErr bool
+	}{
+		{"valid message", ccfg, "feat: add something", "feat: add something", false},
+		{"type typo", ccfg, "feature: add something", "feat: add something", false},
+		{"upper case type", ccfg, "FIX: add something", "fix: add something", false},
+		{"unknown type", ccfg, "other: add something", "other: add something", false},
+		{"spacing", ccfg, "feat ( scope ) !:add something ", "feat(scope)!: add something", false},
+		{"description case", ccfg, "feat: Add something", "feat: add something", false},
+		{"keep acronym", ccfg, "feat: API endpoint", "feat: API endpoint", false},
+		{"sentence case", rulesCfg(map[string]CommitMessageRuleConfig{RuleSubjectCase: {Case: CaseSentence}}), "feat: add something", "feat: Add something", false},
+		{"trailing period", rulesCfg(fullStop), "feat: add something.", "feat: add something", false},
+		{"keep trailing period if rule is off", ccfg, "feat: add something.", "feat: add something.", false},
+		{"keep body", ccfg, "feature(scope): Add something\n\nbody\n\njira: JIRA-123\n", "feat(scope): add something\n\nbody\n\njira: JIRA-123\n", false},
+		{"invalid header", ccfg, "add something", "add something", false},
+		{"type alias", ccfgTypeAliases, "BugFix: Add something", "fix: add something", false},
+		{"header selector", newCommitMessageCfg("Merged PR (\\d+): (?P<header>.*)"), "Merged PR 1: feature: Add something", "Merged PR 1: feat: add something", false},
+		{"invalid rules", rulesCfg(map[string]CommitMessageRuleConfig{"unknown": {}}), "feat: add something", "", true},
+		{"type pattern", ccfgHeaderGrammar, "I18N ( api, ui ): Add something", "i18n(api, ui): add something", false},
+		{"invalid type pattern", CommitMessageConfig{TypePattern: "[a-z"}, "feat: add something", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMessageProcessor(tt.cfg, newBranchCfg(false)).Fix(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MessageProcessorImpl.Fix() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MessageProcessorImpl.Fix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageProcessorImpl_LintSuggestions(t *testing.T) {
+	p := NewMessageProcessor(rulesCfg(map[string]CommitMessageRuleConfig{RuleScopeEnum: {Severity: RuleSeverityOff}}), newBranchCfg(false))
+	got, err := p.Lint("feature(api):Add something")
+	if err != nil {
+		t.Fatalf("MessageProcessorImpl.Lint() error = %v", err)
+	}
+	want := []RuleViolation{withSuggestion(violation(RuleHeaderFormat, RuleSeverityError, "subject [feature(api):Add something] should be valid according with conventional commits", SegmentHeader, 1, 1), "feat(api): add something")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MessageProcessorImpl.Lint() = %+v, want %+v", got, want)
+	}
+
+	got, _ = p.Lint("feature(api): add something")
+	want = []RuleViolation{withSuggestion(violation(RuleTypeEnum, RuleSeverityError, "message type should be one of [feat, fix]", SegmentType, 1, 1), "feat")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MessageProcessorImpl.Lint() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMessageProcessorImpl_suggestType(t *testing.T) {
+	cfg := ccfg
+	cfg.Types = []string{"build", "chore", "docs", "feat", "fix", "refactor", "test"}
+	p := NewMessageProcessor(cfg, newBranchCfg(false))
+	tests := []struct {
+		ctype     string
+		want      string
+		wantFound bool
+	}{
+		{"feature", "feat", true},
+		{"fxi", "fix", true},
+		{"Docs", "docs", true},
+		{"refactoring", "refactor", true},
+		{"tests", "test", true},
+		{"other", "", false},
+		{"release", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ctype, func(t *testing.T) {
+			got, found := p.suggestType(tt.ctype)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("MessageProcessorImpl.suggestType() = %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"feat", "feat", 0},
+		{"feature", "feat", 3},
+		{"fxi", "fix", 2},
+		{"", "fix", 3},
+		{"çafé", "cafe", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
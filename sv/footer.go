@@ -0,0 +1,150 @@
+package sv
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	footerSeparatorColon = ": "
+	footerSeparatorHash  = " #"
+
+	breakingChangeFooterSynonym = "BREAKING-CHANGE"
+
+	scissorsLine = "# ------------------------ >8 ------------------------"
+)
+
+var footerTokenRegex = regexp.MustCompile(`^(` + breakingChangeFooterKey + `|[\w-]+)(: | #)(.*)$`)
+
+// CommitFooter conventional commits footer, eg.: "Refs #123" has token "Refs", separator " #" and value "123".
+type CommitFooter struct {
+	Token     string `json:"token"`
+	Separator string `json:"separator"`
+	Value     string `json:"value"`
+}
+
+// IsBreakingChange return true if footer token is "BREAKING CHANGE" or "BREAKING-CHANGE".
+func (f CommitFooter) IsBreakingChange() bool {
+	return (f.Token == breakingChangeFooterKey || f.Token == breakingChangeFooterSynonym) && f.Separator == footerSeparatorColon
+}
+
+// parseFooters parse footers from commit body according with conventional commits spec.
+// Footers are the last paragraphs of the body, starting after a blank line (or on first body line) with a token line,
+// values can have multiple lines and end when the next token is found.
+func parseFooters(body string) []CommitFooter {
+	_, footers := splitFooterLines(strings.Split(strings.TrimRight(body, "\n"), "\n"))
+	return footers
+}
+
+// splitFooterLines find where footers start on body lines, return len(lines) if there is no footer.
+func splitFooterLines(lines []string) (int, []CommitFooter) {
+	for i := range lines {
+		if (i == 0 || strings.TrimSpace(lines[i-1]) == "") && isFooterTokenLine(lines[i]) {
+			if footers, ok := parseFooterLines(lines[i:]); ok {
+				return i, footers
+			}
+		}
+	}
+	return len(lines), nil
+}
+
+// parseFooterLines parse lines as footers, return false if a paragraph does not start with a token.
+// Breaking change is the only footer that can have multiple paragraphs.
+func parseFooterLines(lines []string) ([]CommitFooter, bool) {
+	var footers []CommitFooter
+	var value []string
+	appendFooter := func() {
+		if len(footers) > 0 {
+			footers[len(footers)-1].Value = strings.TrimSpace(strings.Join(value, "\n"))
+		}
+	}
+
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank = true
+			continue
+		}
+		match := footerTokenRegex.FindStringSubmatch(line)
+		if match == nil {
+			if blank {
+				if !footers[len(footers)-1].IsBreakingChange() { // new paragraph that is not a footer, footers should be the last paragraphs
+					return nil, false
+				}
+				value = append(value, "")
+				blank = false
+			}
+			value = append(value, line)
+			continue
+		}
+
+		appendFooter()
+		footers = append(footers, CommitFooter{Token: match[1], Separator: match[2]})
+		value = []string{match[3]}
+		blank = false
+	}
+	appendFooter()
+	return footers, true
+}
+
+func isFooterTokenLine(line string) bool {
+	return footerTokenRegex.MatchString(strings.TrimRight(line, " \t"))
+}
+
+// footerValues find values of all footers that match config key or synonyms, in message order and without duplicates.
+// Footer values are split using config value separator, hash is kept on values if config uses hash.
+func footerValues(footers []CommitFooter, cfg CommitMessageFooterConfig) []string {
+	if cfg.Key == "" {
+		return nil
+	}
+	separator := footerSeparatorColon
+	if cfg.UseHash {
+		separator = footerSeparatorHash
+	}
+
+	var values []string
+	for _, footer := range footers {
+		if footer.Separator != separator || (footer.Token != cfg.Key && !contains(footer.Token, cfg.KeySynonyms)) {
+			continue
+		}
+		for _, value := range splitFooterValue(footer.Value, cfg.ValueSeparator) {
+			if cfg.UseHash && !strings.HasPrefix(value, "#") {
+				value = "#" + value
+			}
+			if !contains(value, values) {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func splitFooterValue(value, separator string) []string {
+	parts := []string{value}
+	if separator != "" {
+		parts = strings.Split(value, separator)
+	}
+
+	var values []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// stripCommentLines remove lines that git will ignore from a commit message file: comments and everything after scissors line.
+func stripCommentLines(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if line == scissorsLine {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
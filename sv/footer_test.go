@@ -0,0 +1,68 @@
+package sv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseFooters(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []CommitFooter
+	}{
+		{"empty body", "", nil},
+		{"body without footers", "some description\n\nmore description", nil},
+		{"only footers", "Refs #123\nReviewed-by: Z", []CommitFooter{{"Refs", " #", "123"}, {"Reviewed-by", ": ", "Z"}}},
+		{"body and footers", "some description\n\nReviewed-by: Z\nRefs #123\n", []CommitFooter{{"Reviewed-by", ": ", "Z"}, {"Refs", " #", "123"}}},
+		{"breaking change", "BREAKING CHANGE: breaks\nBREAKING-CHANGE: also breaks", []CommitFooter{{"BREAKING CHANGE", ": ", "breaks"}, {"BREAKING-CHANGE", ": ", "also breaks"}}},
+		{"multiline value", "some description\n\nBREAKING CHANGE: first line\nsecond line\nRefs #123", []CommitFooter{{"BREAKING CHANGE", ": ", "first line\nsecond line"}, {"Refs", " #", "123"}}},
+		{"blank line between footers", "BREAKING CHANGE: breaks\n\nRefs #123", []CommitFooter{{"BREAKING CHANGE", ": ", "breaks"}, {"Refs", " #", "123"}}},
+		{"footer without blank line", "some description\nRefs #123", nil},
+		{"multiple paragraphs on breaking change", "BREAKING CHANGE: first paragraph\nstill first\n\nsecond paragraph\n\nRefs #123", []CommitFooter{{"BREAKING CHANGE", ": ", "first paragraph\nstill first\n\nsecond paragraph"}, {"Refs", " #", "123"}}},
+		{"multiple paragraphs on other footer", "some description\n\nNote: first paragraph\n\nsecond paragraph", nil},
+		{"footer before body paragraph", "Refs #123\n\nsome description", nil},
+		{"last paragraph only", "Note: on body\n\nsome description\n\nRefs #123", []CommitFooter{{"Refs", " #", "123"}}},
+		{"token with spaces", "some description\n\nsome token: value", nil},
+		{"trailing spaces on value", "Refs #123  ", []CommitFooter{{"Refs", " #", "123"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseFooters(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFooters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_footerValues(t *testing.T) {
+	footers := []CommitFooter{{"Refs", " #", "123"}, {"Jira", ": ", "JIRA-1"}, {"jira", ": ", "JIRA-2, JIRA-3"}, {"jira", ": ", "JIRA-2"}, {"Refs", " #", "124, #125"}}
+
+	tests := []struct {
+		name string
+		cfg  CommitMessageFooterConfig
+		want []string
+	}{
+		{"key", CommitMessageFooterConfig{Key: "jira"}, []string{"JIRA-2, JIRA-3", "JIRA-2"}},
+		{"synonyms", CommitMessageFooterConfig{Key: "jira", KeySynonyms: []string{"Jira"}}, []string{"JIRA-1", "JIRA-2, JIRA-3", "JIRA-2"}},
+		{"value separator", CommitMessageFooterConfig{Key: "jira", KeySynonyms: []string{"Jira"}, ValueSeparator: ","}, []string{"JIRA-1", "JIRA-2", "JIRA-3"}},
+		{"hash", CommitMessageFooterConfig{Key: "Refs", UseHash: true, ValueSeparator: ","}, []string{"#123", "#124", "#125"}},
+		{"separator mismatch", CommitMessageFooterConfig{Key: "Refs"}, nil},
+		{"not found", CommitMessageFooterConfig{Key: "issue"}, nil},
+		{"empty key", CommitMessageFooterConfig{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := footerValues(footers, tt.cfg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("footerValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stripCommentLines(t *testing.T) {
+	message := "fix: something\n\nRefs #123\n# Please enter the commit message\n#\n" + scissorsLine + "\ndiff --git a/a b/a\n"
+	if got, want := stripCommentLines(message), "fix: something\n\nRefs #123"; got != want {
+		t.Errorf("stripCommentLines() = %q, want %q", got, want)
+	}
+}
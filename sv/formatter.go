@@ -20,12 +20,33 @@ type releaseNoteTemplateVariables struct {
 	AuthorNames []string
 }
 
+type upgradeNoteTemplateVariables struct {
+	From                string
+	To                  string
+	Date                time.Time
+	Releases            []string
+	BreakingChangesName string
+	BreakingChanges     []upgradeNoteBreakingChangeVariables
+	Sections            []ReleaseNoteSection
+	AuthorNames         []string
+}
+
+type upgradeNoteBreakingChangeVariables struct {
+	BreakingChange
+	Release string
+}
+
 // OutputFormatter output formatter interface.
 type OutputFormatter interface {
 	FormatReleaseNote(releasenote ReleaseNote) (string, error)
 	FormatChangelog(releasenotes []ReleaseNote) (string, error)
 }
 
+// UpgradeNoteFormatter output formatter for upgrade notes, optional for OutputFormatter implementations.
+type UpgradeNoteFormatter interface {
+	FormatUpgradeNote(upgradenote UpgradeNote) (string, error)
+}
+
 // OutputFormatterImpl formater for release note and changelog.
 type OutputFormatterImpl struct {
 	templates *template.Template
@@ -34,9 +55,11 @@ type OutputFormatterImpl struct {
 // NewOutputFormatter TemplateProcessor constructor.
 func NewOutputFormatter(templatesFS fs.FS) *OutputFormatterImpl {
 	templateFNs := map[string]interface{}{
-		"timefmt":    timeFormat,
-		"getsection": getSection,
-		"getenv":     os.Getenv,
+		"timefmt":      timeFormat,
+		"getsection":   getSection,
+		"getenv":       os.Getenv,
+		"indent":       indent,
+		"groupbyscope": groupByScope,
 	}
 	tpls := template.Must(template.New("templates").Funcs(templateFNs).ParseFS(templatesFS, "*"))
 	return &OutputFormatterImpl{templates: tpls}
@@ -65,13 +88,18 @@ func (p OutputFormatterImpl) FormatChangelog(releasenotes []ReleaseNote) (string
 	return b.String(), nil
 }
 
-func releaseNoteVariables(releasenote ReleaseNote) releaseNoteTemplateVariables {
-	release := releasenote.Tag
-	if releasenote.Version != nil {
-		release = "v" + releasenote.Version.String()
+// FormatUpgradeNote format an upgrade note.
+func (p OutputFormatterImpl) FormatUpgradeNote(upgradenote UpgradeNote) (string, error) {
+	var b bytes.Buffer
+	if err := p.templates.ExecuteTemplate(&b, "upgradenotes-md.tpl", upgradeNoteVariables(upgradenote)); err != nil {
+		return "", err
 	}
+	return b.String(), nil
+}
+
+func releaseNoteVariables(releasenote ReleaseNote) releaseNoteTemplateVariables {
 	return releaseNoteTemplateVariables{
-		Release:     release,
+		Release:     releaseName(releasenote.Tag, releasenote.Version),
 		Tag:         releasenote.Tag,
 		Version:     releasenote.Version,
 		Date:        releasenote.Date,
@@ -80,6 +108,39 @@ func releaseNoteVariables(releasenote ReleaseNote) releaseNoteTemplateVariables
 	}
 }
 
+func upgradeNoteVariables(upgradenote UpgradeNote) upgradeNoteTemplateVariables {
+	releases := make([]string, len(upgradenote.Releases))
+	for i, r := range upgradenote.Releases {
+		releases[i] = releaseName(r.ReleaseNote.Tag, r.ReleaseNote.Version)
+	}
+	breakingChanges := make([]upgradeNoteBreakingChangeVariables, len(upgradenote.BreakingChanges))
+	for i, bc := range upgradenote.BreakingChanges {
+		breakingChanges[i] = upgradeNoteBreakingChangeVariables{BreakingChange: bc.BreakingChange, Release: releaseName(bc.Tag, bc.Version)}
+	}
+
+	from := ""
+	if upgradenote.From != nil {
+		from = "v" + upgradenote.From.String()
+	}
+	return upgradeNoteTemplateVariables{
+		From:                from,
+		To:                  releaseName(upgradenote.ReleaseNote.Tag, upgradenote.To),
+		Date:                upgradenote.ReleaseNote.Date,
+		Releases:            releases,
+		BreakingChangesName: upgradenote.BreakingChangesName,
+		BreakingChanges:     breakingChanges,
+		Sections:            upgradenote.ReleaseNote.Sections,
+		AuthorNames:         toSortedArray(upgradenote.ReleaseNote.AuthorsNames),
+	}
+}
+
+func releaseName(tag string, version *semver.Version) string {
+	if version != nil {
+		return "v" + version.String()
+	}
+	return tag
+}
+
 func toSortedArray(input map[string]struct{}) []string {
 	result := make([]string, len(input))
 	i := 0
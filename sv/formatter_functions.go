@@ -1,6 +1,9 @@
 package sv
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 func timeFormat(t time.Time, format string) string {
 	if t.IsZero() {
@@ -17,3 +20,41 @@ func getSection(sections []ReleaseNoteSection, name string) ReleaseNoteSection {
 	}
 	return nil
 }
+
+// ScopeGroup commits with the same scope.
+type ScopeGroup struct {
+	Scope string
+	Items []GitCommitLog
+}
+
+// groupByScope group commits by scope in order of first appearance, commits with multiple scopes are added to each group and commits without scope are grouped with an empty scope.
+func groupByScope(items []GitCommitLog) []ScopeGroup {
+	var groups []ScopeGroup
+	index := make(map[string]int)
+	for _, item := range items {
+		scopes := item.Message.Scopes
+		if len(scopes) == 0 {
+			scopes = []string{""}
+		}
+		for _, scope := range scopes {
+			i, exists := index[scope]
+			if !exists {
+				i = len(groups)
+				index[scope] = i
+				groups = append(groups, ScopeGroup{Scope: scope})
+			}
+			groups[i].Items = append(groups[i].Items, item)
+		}
+	}
+	return groups
+}
+
+func indent(spaces int, text string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = strings.Repeat(" ", spaces) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
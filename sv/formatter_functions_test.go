@@ -43,3 +43,45 @@ func Test_getSection(t *testing.T) {
 		})
 	}
 }
+
+func Test_indent(t *testing.T) {
+	tests := []struct {
+		name   string
+		spaces int
+		text   string
+		want   string
+	}{
+		{"single line", 2, "text", "text"},
+		{"multiple lines", 2, "first\nsecond", "first\n  second"},
+		{"empty lines", 4, "first\n\nsecond", "first\n\n    second"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := indent(tt.spaces, tt.text); got != tt.want {
+				t.Errorf("indent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_groupByScope(t *testing.T) {
+	api := GitCommitLog{Hash: "1", Message: CommitMessage{Scope: "api", Scopes: []string{"api"}}}
+	apiUI := GitCommitLog{Hash: "2", Message: CommitMessage{Scope: "api,ui", Scopes: []string{"api", "ui"}}}
+	noScope := GitCommitLog{Hash: "3"}
+	tests := []struct {
+		name  string
+		items []GitCommitLog
+		want  []ScopeGroup
+	}{
+		{"empty", nil, nil},
+		{"single scope", []GitCommitLog{api}, []ScopeGroup{{"api", []GitCommitLog{api}}}},
+		{"multiple scopes", []GitCommitLog{noScope, apiUI, api}, []ScopeGroup{{"", []GitCommitLog{noScope}}, {"api", []GitCommitLog{apiUI, api}}, {"ui", []GitCommitLog{apiUI}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupByScope(tt.items); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupByScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
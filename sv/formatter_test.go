@@ -40,6 +40,10 @@ var fullChangeLog = `## v1.0.0 (2020-05-01)
 ### Breaking Changes
 
 - break change message
+
+### Other Changes
+
+- subject text ()
 `
 
 func TestOutputFormatterImpl_FormatReleaseNote(t *testing.T) {
@@ -86,11 +90,73 @@ func fullReleaseNote(tag string, date time.Time) ReleaseNote {
 		newReleaseNoteCommitsSection("Features", []string{"feat"}, []GitCommitLog{commitlog("feat", map[string]string{}, "a")}),
 		newReleaseNoteCommitsSection("Bug Fixes", []string{"fix"}, []GitCommitLog{commitlog("fix", map[string]string{}, "a")}),
 		newReleaseNoteCommitsSection("Build", []string{"build"}, []GitCommitLog{commitlog("build", map[string]string{}, "a")}),
-		ReleaseNoteBreakingChangeSection{"Breaking Changes", []string{"break change message"}},
+		ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"break change message"}},
+		ReleaseNoteNonConventionalSection{"Other Changes", []GitCommitLog{nonConventionalCommitlog("a")}},
 	}
 	return releaseNote(v, tag, date, sections, map[string]struct{}{"a": {}})
 }
 
+var fullUpgradeNoteOutput = `## Upgrade from v1.3.0 to v2.4.0 (2020-05-01)
+
+### Breaking Changes
+
+- **v2.4.0:** new break change message
+- **v2.0.0:** break change message
+
+### Features
+
+- subject text ()
+
+### Other Changes
+
+- subject text ()
+`
+
+func TestOutputFormatterImpl_FormatUpgradeNote(t *testing.T) {
+	date, _ := time.Parse("2006-01-02", "2020-05-01")
+	withoutFrom := fullUpgradeNote(date)
+	withoutFrom.From = nil
+	withoutFrom.BreakingChanges = nil
+
+	tests := []struct {
+		name  string
+		input UpgradeNote
+		want  string
+	}{
+		{"full upgrade note", fullUpgradeNote(date), fullUpgradeNoteOutput},
+		{"without from and breaking changes", withoutFrom, "## Upgrade to v2.4.0 (2020-05-01)\n\n### Features\n\n- subject text ()\n\n### Other Changes\n\n- subject text ()\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOutputFormatter(templatesFS).FormatUpgradeNote(tt.input)
+			if err != nil {
+				t.Fatalf("OutputFormatterImpl.FormatUpgradeNote() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("OutputFormatterImpl.FormatUpgradeNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func fullUpgradeNote(date time.Time) UpgradeNote {
+	v, _ := semver.NewVersion("2.4.0")
+	sections := []ReleaseNoteSection{
+		newReleaseNoteCommitsSection("Features", []string{"feat"}, []GitCommitLog{commitlog("feat", map[string]string{}, "a")}),
+		ReleaseNoteNonConventionalSection{"Other Changes", []GitCommitLog{nonConventionalCommitlog("a")}},
+	}
+	return UpgradeNote{
+		From:        semver.MustParse("1.3.0"),
+		To:          v,
+		ReleaseNote: releaseNote(v, "v2.4.0", date, sections, map[string]struct{}{"a": {}}),
+		BreakingChanges: []UpgradeNoteBreakingChange{
+			{BreakingChange: BreakingChange{Message: "new break change message"}, Version: v, Tag: "v2.4.0"},
+			{BreakingChange: BreakingChange{Message: "break change message"}, Version: semver.MustParse("2.0.0"), Tag: "v2.0.0"},
+		},
+		BreakingChangesName: "Breaking Changes",
+	}
+}
+
 func Test_checkTemplatesExecution(t *testing.T) {
 	tpls := NewOutputFormatter(templatesFS).templates
 	tests := []struct {
@@ -99,6 +165,7 @@ func Test_checkTemplatesExecution(t *testing.T) {
 	}{
 		{"changelog-md.tpl", changelogVariables("v1.0.0", "v1.0.1")},
 		{"releasenotes-md.tpl", releaseNotesVariables("v1.0.0")},
+		{"upgradenotes-md.tpl", upgradeNoteVariables(fullUpgradeNote(time.Date(2006, 1, 02, 0, 0, 0, 0, time.UTC)))},
 	}
 	for _, tt := range tests {
 		t.Run(tt.template, func(t *testing.T) {
@@ -123,7 +190,7 @@ func releaseNotesVariables(release string) releaseNoteTemplateVariables {
 			newReleaseNoteCommitsSection("Features", []string{"feat"}, []GitCommitLog{commitlog("feat", map[string]string{}, "a")}),
 			newReleaseNoteCommitsSection("Bug Fixes", []string{"fix"}, []GitCommitLog{commitlog("fix", map[string]string{}, "a")}),
 			newReleaseNoteCommitsSection("Build", []string{"build"}, []GitCommitLog{commitlog("build", map[string]string{}, "a")}),
-			ReleaseNoteBreakingChangeSection{"Breaking Changes", []string{"break change message"}},
+			ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"break change message"}},
 		},
 	}
 }
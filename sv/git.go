@@ -3,22 +3,17 @@ package sv
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
 )
 
-const (
-	logSeparator = "###"
-	endLine      = "~~~"
-)
-
 // Git commands.
 type Git interface {
 	LastTag() string
@@ -30,19 +25,50 @@ type Git interface {
 	IsDetached() (bool, error)
 }
 
+// GitContext git commands with context, the context is used to cancel or set a deadline on git commands.
+type GitContext interface {
+	Git
+	LastTagContext(ctx context.Context) (string, error)
+	LogContext(ctx context.Context, lr LogRange) ([]GitCommitLog, error)
+	CommitContext(ctx context.Context, header, body, footer string) error
+	TagContext(ctx context.Context, version semver.Version) (string, error)
+	TagsContext(ctx context.Context) ([]GitTag, error)
+	BranchContext(ctx context.Context) string
+	IsDetachedContext(ctx context.Context) (bool, error)
+}
+
 // GitCommitLog description of a single commit log.
 type GitCommitLog struct {
-	Date       string        `json:"date,omitempty"`
-	Timestamp  int           `json:"timestamp,omitempty"`
-	AuthorName string        `json:"authorName,omitempty"`
-	Hash       string        `json:"hash,omitempty"`
-	Message    CommitMessage `json:"message,omitempty"`
+	Date           string          `json:"date,omitempty"`
+	Timestamp      int             `json:"timestamp,omitempty"`
+	AuthorName     string          `json:"authorName,omitempty"`
+	AuthorEmail    string          `json:"authorEmail,omitempty"`
+	AuthorDate     time.Time       `json:"authorDate"`
+	CommitterName  string          `json:"committerName,omitempty"`
+	CommitterEmail string          `json:"committerEmail,omitempty"`
+	CommitterDate  time.Time       `json:"committerDate"`
+	Hash           string          `json:"hash,omitempty"`
+	FullHash       string          `json:"fullHash,omitempty"`
+	Parents        []string        `json:"parents,omitempty"`
+	Refs           []string        `json:"refs,omitempty"`
+	Files          []GitCommitFile `json:"files,omitempty"`
+	Subject        string          `json:"subject,omitempty"`    // Raw subject line, as written by commit author.
+	RawMessage     string          `json:"rawMessage,omitempty"` // Full message, as written by commit author.
+	Message        CommitMessage   `json:"message,omitempty"`
+}
+
+// GitCommitFile file changed by a commit, additions and deletions are -1 for binary files.
+type GitCommitFile struct {
+	Path      string `json:"path"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
 }
 
 // GitTag git tag info.
 type GitTag struct {
 	Name string
 	Date time.Time
+	Hash string // Full hash of the tagged commit.
 }
 
 // LogRangeType type of log range.
@@ -53,6 +79,9 @@ const (
 	TagRange  LogRangeType = "tag"
 	DateRange LogRangeType = "date"
 	HashRange LogRangeType = "hash"
+	// VersionRange selects commits of released versions, start and end are included,
+	// it must be resolved to a TagRange using Releaser.ResolveVersionRange before calling Git.Log.
+	VersionRange LogRangeType = "version"
 )
 
 // LogRange git log range.
@@ -67,35 +96,106 @@ func NewLogRange(t LogRangeType, start, end string) LogRange {
 	return LogRange{rangeType: t, start: start, end: end}
 }
 
+// Type range type.
+func (lr LogRange) Type() LogRangeType {
+	return lr.rangeType
+}
+
+// Start range start, empty if not defined.
+func (lr LogRange) Start() string {
+	return lr.start
+}
+
+// End range end, empty if not defined.
+func (lr LogRange) End() string {
+	return lr.end
+}
+
+// GitRepository git repository location and git command preferences.
+type GitRepository struct {
+	Path   string   // Repository path, if empty, process working directory will be used.
+	Binary string   // Git binary, if empty, "git" from PATH will be used.
+	Env    []string // Environment variables added to process environment on every git command, eg.: "GIT_CONFIG_NOSYSTEM=1".
+}
+
 // GitImpl git command implementation.
 type GitImpl struct {
+	repo             GitRepository
 	messageProcessor MessageProcessor
 	tagCfg           TagConfig
+	logCfg           LogConfig
+	cache            *Cache
 }
 
 // NewGit constructor.
-func NewGit(messageProcessor MessageProcessor, cfg TagConfig) *GitImpl {
+func NewGit(repo GitRepository, messageProcessor MessageProcessor, tcfg TagConfig, lcfg LogConfig) *GitImpl {
 	return &GitImpl{
+		repo:             repo,
 		messageProcessor: messageProcessor,
-		tagCfg:           cfg,
+		tagCfg:           tcfg,
+		logCfg:           lcfg,
+	}
+}
+
+// WithCache return a copy that reuses parsed commit messages from cache and stores new ones on it.
+func (g GitImpl) WithCache(cache *Cache) *GitImpl {
+	g.cache = cache
+	return &g
+}
+
+func (g GitImpl) command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, str(g.repo.Binary, "git"), args...)
+	cmd.Dir = g.repo.Path
+	if len(g.repo.Env) > 0 {
+		cmd.Env = append(os.Environ(), g.repo.Env...)
 	}
+	return cmd
 }
 
 // LastTag get last tag, if no tag found, return empty.
 func (g GitImpl) LastTag() string {
-	cmd := exec.Command("git", "for-each-ref", "refs/tags/"+*g.tagCfg.Filter, "--sort", "-creatordate", "--format", "%(refname:short)", "--count", "1")
+	tag, _ := g.LastTagContext(context.Background())
+	return tag
+}
+
+// LastTagContext get last tag, if no tag found, return empty.
+func (g GitImpl) LastTagContext(ctx context.Context) (string, error) {
+	cmd := g.command(ctx, "for-each-ref", "refs/tags/"+*g.tagCfg.Filter, "--sort", "-creatordate", "--format", "%(refname:short)", "--count", "1")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return ""
+		return "", combinedOutputErr(ctx, err, out)
 	}
-	return strings.TrimSpace(strings.Trim(string(out), "\n"))
+	return strings.TrimSpace(strings.Trim(string(out), "\n")), nil
 }
 
 // Log return git log.
 func (g GitImpl) Log(lr LogRange) ([]GitCommitLog, error) {
-	format := "--pretty=format:\"%ad" + logSeparator + "%at" + logSeparator + "%cN" + logSeparator + "%h" + logSeparator + "%s" + logSeparator + "%b" + endLine + "\""
-	params := []string{"log", "--date=short", format}
+	return g.LogContext(context.Background(), lr)
+}
 
+// LogContext return git log.
+func (g GitImpl) LogContext(ctx context.Context, lr LogRange) ([]GitCommitLog, error) {
+	if err := g.logCfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := []string{"log", "-z", "--date=short", "--decorate=short", "--pretty=format:" + logFormat()}
+	if g.logCfg.Files {
+		params = append(params, "--numstat")
+	}
+	if g.logCfg.FirstParent {
+		params = append(params, "--first-parent")
+	}
+	switch g.logCfg.Merges {
+	case LogMergesSkip:
+		params = append(params, "--no-merges")
+	case LogMergesOnly:
+		params = append(params, "--merges")
+	}
+
+	if lr.rangeType == VersionRange {
+		return nil, fmt.Errorf("version range %s..%s must be resolved to a tag range", lr.start, lr.end)
+	}
 	if lr.start != "" || lr.end != "" {
 		switch lr.rangeType {
 		case DateRange:
@@ -109,12 +209,25 @@ func (g GitImpl) Log(lr LogRange) ([]GitCommitLog, error) {
 		}
 	}
 
-	cmd := exec.Command("git", params...)
-	out, err := cmd.CombinedOutput()
+	cmd := g.command(ctx, params...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, combinedOutputErr(err, out)
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, outputErr(ctx, err)
+	}
+
+	// commits are parsed while git log is still writing, so output is never fully loaded in memory.
+	logs, parseErr := parseLogOutput(g.messageProcessor, g.logCfg, g.cache, stdout)
+	if parseErr != nil {
+		_ = cmd.Process.Kill()
+	}
+	if err := cmd.Wait(); err != nil && (parseErr == nil || ctx.Err() != nil) {
+		return nil, combinedOutputErr(ctx, err, stderr.Bytes())
 	}
-	logs, parseErr := parseLogOutput(g.messageProcessor, string(out))
 	if parseErr != nil {
 		return nil, parseErr
 	}
@@ -123,42 +236,65 @@ func (g GitImpl) Log(lr LogRange) ([]GitCommitLog, error) {
 
 // Commit runs git commit.
 func (g GitImpl) Commit(header, body, footer string) error {
-	cmd := exec.Command("git", "commit", "-m", header, "-m", "", "-m", body, "-m", "", "-m", footer)
+	return g.CommitContext(context.Background(), header, body, footer)
+}
+
+// CommitContext runs git commit.
+func (g GitImpl) CommitContext(ctx context.Context, header, body, footer string) error {
+	cmd := g.command(ctx, "commit", "-m", header, "-m", "", "-m", body, "-m", "", "-m", footer)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	if err := cmd.Run(); err != nil {
+		return outputErr(ctx, err)
+	}
+	return nil
 }
 
 // Tag create a git tag.
 func (g GitImpl) Tag(version semver.Version) (string, error) {
-	tag := fmt.Sprintf(*g.tagCfg.Pattern, version.Major(), version.Minor(), version.Patch())
+	return g.TagContext(context.Background(), version)
+}
+
+// TagContext create a git tag.
+func (g GitImpl) TagContext(ctx context.Context, version semver.Version) (string, error) {
+	tag := g.tagCfg.TagName(version)
 	tagMsg := fmt.Sprintf("Version %d.%d.%d", version.Major(), version.Minor(), version.Patch())
 
-	tagCommand := exec.Command("git", "tag", "-a", tag, "-m", tagMsg)
+	tagCommand := g.command(ctx, "tag", "-a", tag, "-m", tagMsg)
 	if out, err := tagCommand.CombinedOutput(); err != nil {
-		return tag, combinedOutputErr(err, out)
+		return tag, combinedOutputErr(ctx, err, out)
 	}
 
-	pushCommand := exec.Command("git", "push", "origin", tag)
+	pushCommand := g.command(ctx, "push", "origin", tag)
 	if out, err := pushCommand.CombinedOutput(); err != nil {
-		return tag, combinedOutputErr(err, out)
+		return tag, combinedOutputErr(ctx, err, out)
 	}
 	return tag, nil
 }
 
 // Tags list repository tags.
 func (g GitImpl) Tags() ([]GitTag, error) {
-	cmd := exec.Command("git", "for-each-ref", "--sort", "creatordate", "--format", "%(creatordate:iso8601)#%(refname:short)", "refs/tags/"+*g.tagCfg.Filter)
+	return g.TagsContext(context.Background())
+}
+
+// TagsContext list repository tags.
+func (g GitImpl) TagsContext(ctx context.Context) ([]GitTag, error) {
+	cmd := g.command(ctx, "for-each-ref", "--sort", "creatordate", "--format", "%(creatordate:iso8601)#%(refname:short)#%(if)%(*objectname)%(then)%(*objectname)%(else)%(objectname)%(end)", "refs/tags/"+*g.tagCfg.Filter)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, combinedOutputErr(err, out)
+		return nil, combinedOutputErr(ctx, err, out)
 	}
 	return parseTagsOutput(string(out))
 }
 
 // Branch get git branch.
-func (GitImpl) Branch() string {
-	cmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
+func (g GitImpl) Branch() string {
+	return g.BranchContext(context.Background())
+}
+
+// BranchContext get git branch.
+func (g GitImpl) BranchContext(ctx context.Context) string {
+	cmd := g.command(ctx, "symbolic-ref", "--short", "HEAD")
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return ""
@@ -167,8 +303,13 @@ func (GitImpl) Branch() string {
 }
 
 // IsDetached check if is detached.
-func (GitImpl) IsDetached() (bool, error) {
-	cmd := exec.Command("git", "symbolic-ref", "-q", "HEAD")
+func (g GitImpl) IsDetached() (bool, error) {
+	return g.IsDetachedContext(context.Background())
+}
+
+// IsDetachedContext check if is detached.
+func (g GitImpl) IsDetachedContext(ctx context.Context) (bool, error) {
+	cmd := g.command(ctx, "symbolic-ref", "-q", "HEAD")
 	out, err := cmd.CombinedOutput()
 	if output := string(out); err != nil { //-q: do not issue an error message if the <name> is not a symbolic ref, but a detached HEAD; instead exit with non-zero status silently.
 		if output == "" {
@@ -186,88 +327,109 @@ func parseTagsOutput(input string) ([]GitTag, error) {
 		if line := strings.TrimSpace(scanner.Text()); line != "" {
 			values := strings.Split(line, "#")
 			date, _ := time.Parse("2006-01-02 15:04:05 -0700", values[0]) // ignore invalid dates
-			result = append(result, GitTag{Name: values[1], Date: date})
+			tag := GitTag{Name: values[1], Date: date}
+			if len(values) > 2 {
+				tag.Hash = values[2]
+			}
+			result = append(result, tag)
 		}
 	}
 	return result, nil
 }
 
-func parseLogOutput(messageProcessor MessageProcessor, log string) ([]GitCommitLog, error) {
-	scanner := bufio.NewScanner(strings.NewReader(log))
-	scanner.Split(splitAt([]byte(endLine)))
-	var logs []GitCommitLog
-	for scanner.Scan() {
-		if text := strings.TrimSpace(strings.Trim(scanner.Text(), "\"")); text != "" {
-			log, err := parseCommitLog(messageProcessor, text)
-			if err != nil {
-				return nil, err
-			}
-			logs = append(logs, log)
-		}
+func addDay(value string) string {
+	if value == "" {
+		return value
 	}
-	return logs, nil
-}
 
-func parseCommitLog(messageProcessor MessageProcessor, commit string) (GitCommitLog, error) {
-	content := strings.Split(strings.Trim(commit, "\""), logSeparator)
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil { // keep original value if is not date format
+		return value
+	}
 
-	timestamp, _ := strconv.Atoi(content[1])
-	message, err := messageProcessor.Parse(content[4], content[5])
+	return t.AddDate(0, 0, 1).Format("2006-01-02")
+}
 
-	if err != nil {
-		return GitCommitLog{}, err
+func str(value, defaultValue string) string {
+	if value != "" {
+		return value
 	}
+	return defaultValue
+}
 
-	return GitCommitLog{
-		Date:       content[0],
-		Timestamp:  timestamp,
-		AuthorName: content[2],
-		Hash:       content[3],
-		Message:    message,
-	}, nil
+func outputErr(ctx context.Context, err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return combinedOutputErr(ctx, err, exitErr.Stderr)
+	}
+	return err
 }
 
-func splitAt(b []byte) func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-		dataLen := len(data)
+func combinedOutputErr(ctx context.Context, err error, out []byte) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return fmt.Errorf("git command aborted: %w", ctxErr)
+	}
+	msg := strings.Split(string(out), "\n")
+	return fmt.Errorf("%v - %s", err, msg[0])
+}
 
-		if atEOF && dataLen == 0 {
-			return 0, nil, nil
-		}
+// withContext return git as GitContext, commands of a Git without context support only check if context is done before running.
+func withContext(git Git) GitContext {
+	if g, ok := git.(GitContext); ok {
+		return g
+	}
+	return contextGit{git}
+}
 
-		if i := bytes.Index(data, b); i >= 0 {
-			return i + len(b), data[0:i], nil
-		}
+type contextGit struct {
+	Git
+}
 
-		if atEOF {
-			return dataLen, data, nil
-		}
+func (g contextGit) LastTagContext(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return g.LastTag(), nil
+}
 
-		return 0, nil, nil
+func (g contextGit) LogContext(ctx context.Context, lr LogRange) ([]GitCommitLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
+	return g.Log(lr)
 }
 
-func addDay(value string) string {
-	if value == "" {
-		return value
+func (g contextGit) CommitContext(ctx context.Context, header, body, footer string) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
+	return g.Commit(header, body, footer)
+}
 
-	t, err := time.Parse("2006-01-02", value)
-	if err != nil { // keep original value if is not date format
-		return value
+func (g contextGit) TagContext(ctx context.Context, version semver.Version) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
 	}
+	return g.Tag(version)
+}
 
-	return t.AddDate(0, 0, 1).Format("2006-01-02")
+func (g contextGit) TagsContext(ctx context.Context) ([]GitTag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return g.Tags()
 }
 
-func str(value, defaultValue string) string {
-	if value != "" {
-		return value
+func (g contextGit) BranchContext(ctx context.Context) string {
+	if ctx.Err() != nil {
+		return ""
 	}
-	return defaultValue
+	return g.Branch()
 }
 
-func combinedOutputErr(err error, out []byte) error {
-	msg := strings.Split(string(out), "\n")
-	return fmt.Errorf("%v - %s", err, msg[0])
+func (g contextGit) IsDetachedContext(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return g.IsDetached()
 }
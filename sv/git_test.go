@@ -1,6 +1,9 @@
 package sv
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -15,6 +18,7 @@ func Test_parseTagsOutput(t *testing.T) {
 	}{
 		{"with date", "2020-05-01 18:00:00 -0300#1.0.0", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300")}}, false},
 		{"without date", "#1.0.0", []GitTag{{Name: "1.0.0", Date: time.Time{}}}, false},
+		{"with hash", "2020-05-01 18:00:00 -0300#1.0.0#3f1a4b5c", []GitTag{{Name: "1.0.0", Date: date("2020-05-01 18:00:00 -0300"), Hash: "3f1a4b5c"}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -37,3 +41,34 @@ func date(input string) time.Time {
 	}
 	return t
 }
+
+func TestGitImpl_command(t *testing.T) {
+	t.Setenv("SV4GIT_TEST", "from-process")
+	tests := []struct {
+		name     string
+		repo     GitRepository
+		wantPath string
+		wantDir  string
+		wantEnv  []string
+	}{
+		{"default", GitRepository{}, "git", "", nil},
+		{"repository", GitRepository{Path: "/tmp/repo", Binary: "/opt/git/bin/git", Env: []string{"GIT_CONFIG_NOSYSTEM=1"}}, "/opt/git/bin/git", "/tmp/repo", append(os.Environ(), "GIT_CONFIG_NOSYSTEM=1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewGit(tt.repo, nil, TagConfig{}, LogConfig{}).command(context.Background(), "status")
+			if path := cmd.Path; path != tt.wantPath && filepath.Base(path) != tt.wantPath {
+				t.Errorf("GitImpl.command() path = %v, want %v", path, tt.wantPath)
+			}
+			if want := []string{str(tt.repo.Binary, "git"), "status"}; !reflect.DeepEqual(cmd.Args, want) {
+				t.Errorf("GitImpl.command() args = %v, want %v", cmd.Args, want)
+			}
+			if cmd.Dir != tt.wantDir {
+				t.Errorf("GitImpl.command() dir = %v, want %v", cmd.Dir, tt.wantDir)
+			}
+			if !reflect.DeepEqual(cmd.Env, tt.wantEnv) {
+				t.Errorf("GitImpl.command() env = %v, want %v", cmd.Env, tt.wantEnv)
+			}
+		})
+	}
+}
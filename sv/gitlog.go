@@ -0,0 +1,229 @@
+package sv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	logRecordSeparator = '\x1e'
+	logFieldSeparator  = '\x00'
+)
+
+// git log fields, each field is terminated by logFieldSeparator and each record starts with logRecordSeparator.
+const (
+	logFieldDate = iota
+	logFieldTimestamp
+	logFieldAuthorName
+	logFieldAuthorEmail
+	logFieldAuthorDate
+	logFieldCommitterName
+	logFieldCommitterEmail
+	logFieldCommitterDate
+	logFieldHash
+	logFieldFullHash
+	logFieldParents
+	logFieldRefs
+	logFieldSubject
+	logFieldBody
+	logFieldRawMessage
+	logFieldsCount
+)
+
+var logFieldPlaceholders = [logFieldsCount]string{
+	logFieldDate:           "%ad",
+	logFieldTimestamp:      "%at",
+	logFieldAuthorName:     "%aN",
+	logFieldAuthorEmail:    "%aE",
+	logFieldAuthorDate:     "%aI",
+	logFieldCommitterName:  "%cN",
+	logFieldCommitterEmail: "%cE",
+	logFieldCommitterDate:  "%cI",
+	logFieldHash:           "%h",
+	logFieldFullHash:       "%H",
+	logFieldParents:        "%P",
+	logFieldRefs:           "%D",
+	logFieldSubject:        "%s",
+	logFieldBody:           "%b",
+	logFieldRawMessage:     "%B",
+}
+
+// ErrMalformedLog is returned, wrapped on a LogParseError, when git log output does not match expected format.
+var ErrMalformedLog = errors.New("malformed git log record")
+
+// LogParseError error parsing a single git log record.
+type LogParseError struct {
+	Record int    // Record position on git log output, starting at 0.
+	Hash   string // Commit hash, empty if record is malformed before hash field.
+	Err    error
+}
+
+func (e *LogParseError) Error() string {
+	if e.Hash != "" {
+		return fmt.Sprintf("could not parse git log record %d (%s): %v", e.Record, e.Hash, e.Err)
+	}
+	return fmt.Sprintf("could not parse git log record %d: %v", e.Record, e.Err)
+}
+
+func (e *LogParseError) Unwrap() error {
+	return e.Err
+}
+
+func logFormat() string {
+	var format strings.Builder
+	format.WriteString("%x1e")
+	for _, placeholder := range logFieldPlaceholders {
+		format.WriteString(placeholder)
+		format.WriteString("%x00")
+	}
+	return format.String()
+}
+
+func parseLogOutput(messageProcessor MessageProcessor, cfg LogConfig, cache *Cache, r io.Reader) ([]GitCommitLog, error) {
+	reader := bufio.NewReader(r)
+	if _, err := reader.ReadString(logRecordSeparator); err != nil {
+		if err == io.EOF {
+			return nil, nil // empty log
+		}
+		return nil, err
+	}
+
+	var logs []GitCommitLog
+	for record, last := 0, false; !last; record++ {
+		fields, err := readLogFields(reader)
+		if err != nil {
+			return nil, &LogParseError{Record: record, Hash: hashFromFields(fields), Err: err}
+		}
+
+		extra, err := reader.ReadString(logRecordSeparator)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		last = err == io.EOF
+
+		log, err := parseCommitLog(messageProcessor, cfg, cache, fields, strings.TrimSuffix(extra, string(logRecordSeparator)))
+		if err != nil {
+			return nil, &LogParseError{Record: record, Hash: fields[logFieldHash], Err: err}
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+func readLogFields(reader *bufio.Reader) ([]string, error) {
+	fields := make([]string, 0, logFieldsCount)
+	for len(fields) < logFieldsCount {
+		field, err := reader.ReadString(logFieldSeparator)
+		if err == io.EOF {
+			return fields, fmt.Errorf("%w: expected %d fields, found %d", ErrMalformedLog, logFieldsCount, len(fields))
+		}
+		if err != nil {
+			return fields, err
+		}
+		fields = append(fields, strings.TrimSuffix(field, string(logFieldSeparator)))
+	}
+	return fields, nil
+}
+
+func hashFromFields(fields []string) string {
+	if len(fields) > logFieldHash {
+		return fields[logFieldHash]
+	}
+	return ""
+}
+
+func parseCommitLog(messageProcessor MessageProcessor, cfg LogConfig, cache *Cache, fields []string, numstat string) (GitCommitLog, error) {
+	timestamp, err := strconv.Atoi(fields[logFieldTimestamp])
+	if err != nil {
+		return GitCommitLog{}, fmt.Errorf("%w: invalid timestamp %q", ErrMalformedLog, fields[logFieldTimestamp])
+	}
+	authorDate, _ := time.Parse(time.RFC3339, fields[logFieldAuthorDate])       // ignore invalid dates
+	committerDate, _ := time.Parse(time.RFC3339, fields[logFieldCommitterDate]) // ignore invalid dates
+
+	parents := strings.Fields(fields[logFieldParents])
+	message, cached := cache.Message(fields[logFieldFullHash])
+	if !cached {
+		if message, err = parseCommitMessage(messageProcessor, cfg, fields[logFieldSubject], fields[logFieldBody], len(parents) > 1); err != nil {
+			return GitCommitLog{}, err
+		}
+		cache.SetMessage(fields[logFieldFullHash], message)
+	}
+
+	return GitCommitLog{
+		Date:           fields[logFieldDate],
+		Timestamp:      timestamp,
+		AuthorName:     fields[logFieldAuthorName],
+		AuthorEmail:    fields[logFieldAuthorEmail],
+		AuthorDate:     authorDate,
+		CommitterName:  fields[logFieldCommitterName],
+		CommitterEmail: fields[logFieldCommitterEmail],
+		CommitterDate:  committerDate,
+		Hash:           fields[logFieldHash],
+		FullHash:       fields[logFieldFullHash],
+		Parents:        parents,
+		Refs:           parseRefs(fields[logFieldRefs]),
+		Files:          parseNumstat(numstat),
+		Subject:        fields[logFieldSubject],
+		RawMessage:     fields[logFieldRawMessage],
+		Message:        message,
+	}, nil
+}
+
+func parseCommitMessage(messageProcessor MessageProcessor, cfg LogConfig, subject, body string, merge bool) (CommitMessage, error) {
+	body = strings.TrimSpace(body)
+	if cfg.MergeBody && merge && body != "" {
+		subject, body = splitCommitMessageContent(body)
+		body = strings.TrimSpace(body)
+	}
+
+	message, err := messageProcessor.Parse(subject, body)
+	if cfg.Lenient && errors.Is(err, ErrNonConventionalMessage) {
+		return NewNonConventionalCommitMessage(subject, removeCarriage(body)), nil
+	}
+	if cfg.Lenient && err == nil && message.Type == "" {
+		message.NonConventional = true
+	}
+	return message, err
+}
+
+func parseRefs(input string) []string {
+	var refs []string
+	for _, ref := range strings.Split(input, ",") {
+		if ref = strings.TrimSpace(ref); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// parseNumstat parse git log --numstat -z output, renamed files are represented as "<added>\t<deleted>\t\0<old path>\0<new path>".
+func parseNumstat(input string) []GitCommitFile {
+	var files []GitCommitFile
+	entries := strings.Split(input, string(logFieldSeparator))
+	for i := 0; i < len(entries); i++ {
+		values := strings.SplitN(strings.TrimLeft(entries[i], "\n"), "\t", 3)
+		if len(values) != 3 {
+			continue
+		}
+		path := values[2]
+		if path == "" && i+2 < len(entries) {
+			path = entries[i+2]
+			i += 2
+		}
+		files = append(files, GitCommitFile{Path: path, Additions: numstatValue(values[0]), Deletions: numstatValue(values[1])})
+	}
+	return files
+}
+
+func numstatValue(value string) int {
+	v, err := strconv.Atoi(value)
+	if err != nil { // binary files use "-" instead of a number
+		return -1
+	}
+	return v
+}
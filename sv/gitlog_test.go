@@ -0,0 +1,237 @@
+package sv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func logRecord(hash, subject, body, numstat string) string {
+	fields := [logFieldsCount]string{
+		logFieldDate:           "2020-05-01",
+		logFieldTimestamp:      "1588366800",
+		logFieldAuthorName:     "author",
+		logFieldAuthorEmail:    "author@example.com",
+		logFieldAuthorDate:     "2020-05-01T18:00:00-03:00",
+		logFieldCommitterName:  "committer",
+		logFieldCommitterEmail: "committer@example.com",
+		logFieldCommitterDate:  "2020-05-02T18:00:00-03:00",
+		logFieldHash:           hash,
+		logFieldFullHash:       hash + "000000",
+		logFieldParents:        "p1 p2",
+		logFieldRefs:           "tag: v1.0.0",
+		logFieldSubject:        subject,
+		logFieldBody:           body,
+	}
+	return "\x1e" + strings.Join(fields[:], "\x00") + "\x00" + numstat
+}
+
+func commitLogRecord(hash, ctype, description, body string, files []GitCommitFile) GitCommitLog {
+	subject := description
+	if ctype != "" {
+		subject = ctype + ": " + description
+	}
+	return GitCommitLog{
+		Date:           "2020-05-01",
+		Timestamp:      1588366800,
+		AuthorName:     "author",
+		AuthorEmail:    "author@example.com",
+		AuthorDate:     rfc3339("2020-05-01T18:00:00-03:00"),
+		CommitterName:  "committer",
+		CommitterEmail: "committer@example.com",
+		CommitterDate:  rfc3339("2020-05-02T18:00:00-03:00"),
+		Hash:           hash,
+		FullHash:       hash + "000000",
+		Parents:        []string{"p1", "p2"},
+		Refs:           []string{"tag: v1.0.0"},
+		Files:          files,
+		Subject:        subject,
+		Message:        CommitMessage{Type: ctype, Description: description, Body: body, Metadata: map[string]string{}},
+	}
+}
+
+func Test_parseLogOutputLenient(t *testing.T) {
+	nonConventional := commitLogRecord("b2", "", "Merge branch 'x'", "", nil)
+	nonConventional.Message.NonConventional = true
+	conventional := commitLogRecord("a1", "feat", "something", "", nil)
+	conventional.Subject = "Merged PR 1: feat: something"
+	input := logRecord("a1", "Merged PR 1: feat: something", "", "") + logRecord("b2", "Merge branch 'x'", "", "")
+
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		lenient bool
+		want    []GitCommitLog
+		wantErr error
+	}{
+		{"strict", newCommitMessageCfg("Merged PR (\\d+): (?P<header>.*)"), false, nil, ErrNonConventionalMessage},
+		{"lenient", newCommitMessageCfg("Merged PR (\\d+): (?P<header>.*)"), true, []GitCommitLog{conventional, nonConventional}, nil},
+		{"lenient without header selector", ccfg, true, []GitCommitLog{conventional, nonConventional}, nil},
+		{"lenient with invalid header selector", newCommitMessageCfg("Merged PR (\\d+): (?P<header>.*"), true, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLogOutput(NewMessageProcessor(tt.cfg, newBranchCfg(false)), LogConfig{Lenient: tt.lenient}, nil, strings.NewReader(input))
+			if tt.want == nil && err == nil {
+				t.Errorf("parseLogOutput() error = %v, want not nil", err)
+				return
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseLogOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLogOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseLogOutputMergeBody(t *testing.T) {
+	mergeRecord := logRecord("a1", "Merge pull request #12 from x/y", "feat: add thing\n\nmore details\n", "")
+	mergeCommit := commitLogRecord("a1", "feat", "add thing", "more details", nil)
+	mergeCommit.Subject = "Merge pull request #12 from x/y"
+	mergeWithoutBody := commitLogRecord("a1", "", "Merge pull request #12 from x/y", "", nil)
+	nonMergeRecord := strings.Replace(logRecord("a1", "fix: something", "feat: add thing", ""), "p1 p2", "p1", 1)
+	nonMergeCommit := commitLogRecord("a1", "fix", "something", "feat: add thing", nil)
+	nonMergeCommit.Parents = []string{"p1"}
+	nonMergeCommit.Message.Footers = []CommitFooter{{"feat", ": ", "add thing"}} // single line body is a valid footer
+
+	tests := []struct {
+		name  string
+		cfg   LogConfig
+		input string
+		want  []GitCommitLog
+	}{
+		{"merge body enabled", LogConfig{MergeBody: true}, mergeRecord, []GitCommitLog{mergeCommit}},
+		{"merge body enabled without body", LogConfig{MergeBody: true}, logRecord("a1", "Merge pull request #12 from x/y", "", ""), []GitCommitLog{mergeWithoutBody}},
+		{"merge body enabled on non-merge commit", LogConfig{MergeBody: true}, nonMergeRecord, []GitCommitLog{nonMergeCommit}},
+		{"merge body disabled", LogConfig{}, logRecord("a1", "Merge pull request #12 from x/y", "", ""), []GitCommitLog{mergeWithoutBody}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLogOutput(NewMessageProcessor(ccfg, newBranchCfg(false)), tt.cfg, nil, strings.NewReader(tt.input))
+			if err != nil {
+				t.Errorf("parseLogOutput() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLogOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func rfc3339(input string) time.Time {
+	t, err := time.Parse(time.RFC3339, input)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var bodyWithSeparators = `### Markdown heading
+
+~~~
+code fence
+~~~`
+
+func Test_parseLogOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []GitCommitLog
+		wantErr error
+	}{
+		{"empty", "", nil, nil},
+		{"single record", logRecord("a1", "feat: something", "", ""), []GitCommitLog{commitLogRecord("a1", "feat", "something", "", nil)}, nil},
+		{"multiple records", logRecord("a1", "feat: something", "", "") + "\x00" + logRecord("b2", "fix: other thing", "body\n", ""), []GitCommitLog{commitLogRecord("a1", "feat", "something", "", nil), commitLogRecord("b2", "fix", "other thing", "body", nil)}, nil},
+		{"separators on body", logRecord("a1", "feat: something", bodyWithSeparators, ""), []GitCommitLog{commitLogRecord("a1", "feat", "something", bodyWithSeparators, nil)}, nil},
+		{"record separator on body", logRecord("a1", "feat: something", "a\x1eb", ""), []GitCommitLog{commitLogRecord("a1", "feat", "something", "a\x1eb", nil)}, nil},
+		{"with numstat", logRecord("a1", "feat: something", "", "\n1\t2\ta.txt\x00\x00") + logRecord("b2", "fix: other thing", "", "\n3\t4\tb.txt\x00"), []GitCommitLog{commitLogRecord("a1", "feat", "something", "", []GitCommitFile{{Path: "a.txt", Additions: 1, Deletions: 2}}), commitLogRecord("b2", "fix", "other thing", "", []GitCommitFile{{Path: "b.txt", Additions: 3, Deletions: 4}})}, nil},
+		{"truncated record", "\x1e2020-05-01\x001588366800\x00author\x00", nil, ErrMalformedLog},
+		{"invalid timestamp", strings.Replace(logRecord("a1", "feat: something", "", ""), "1588366800", "abc", 1), nil, ErrMalformedLog},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLogOutput(NewMessageProcessor(ccfg, newBranchCfg(false)), LogConfig{}, nil, strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseLogOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var parseErr *LogParseError
+			if tt.wantErr != nil && !errors.As(err, &parseErr) {
+				t.Errorf("parseLogOutput() error = %v, want LogParseError", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLogOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseRefs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"empty", "", nil},
+		{"single ref", "tag: v1.0.0", []string{"tag: v1.0.0"}},
+		{"multiple refs", "HEAD -> master, tag: v1.0.0, origin/master", []string{"HEAD -> master", "tag: v1.0.0", "origin/master"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRefs(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRefs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseNumstat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []GitCommitFile
+	}{
+		{"empty", "", nil},
+		{"text files", "1\t0\ta.txt\x0010\t2\tdir/b.txt", []GitCommitFile{{Path: "a.txt", Additions: 1, Deletions: 0}, {Path: "dir/b.txt", Additions: 10, Deletions: 2}}},
+		{"binary file", "-\t-\timage.png", []GitCommitFile{{Path: "image.png", Additions: -1, Deletions: -1}}},
+		{"-z output", "\n1\t0\ta.txt\x00-\t-\timage.png\x00\x00", []GitCommitFile{{Path: "a.txt", Additions: 1, Deletions: 0}, {Path: "image.png", Additions: -1, Deletions: -1}}},
+		{"renamed file", "\n0\t0\t\x00old.txt\x00new.txt\x001\t1\tb.txt\x00", []GitCommitFile{{Path: "new.txt", Additions: 0, Deletions: 0}, {Path: "b.txt", Additions: 1, Deletions: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseNumstat(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNumstat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Benchmark_parseLogOutput(b *testing.B) {
+	var input strings.Builder
+	for i := 0; i < 1000; i++ {
+		input.WriteString(logRecord(fmt.Sprintf("a%d", i), "feat(scope): something", "some body\n\njira: JIRA-123\nRefs #456", "1\t2\tfile.go\x00"))
+	}
+	p := NewMessageProcessor(ccfg, newBranchCfg(false))
+	cache, _ := NewCache(b.TempDir(), "config")
+
+	for _, bb := range []struct {
+		name  string
+		cache *Cache
+	}{{"no cache", nil}, {"cache", cache}} {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := parseLogOutput(p, LogConfig{Files: true}, bb.cache, strings.NewReader(input.String())); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package sv
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	typeGroupName        = "type"
+	scopeGroupName       = "scope"
+	breakingGroupName    = "breaking"
+	descriptionGroupName = "description"
+)
+
+// headerGrammar regexes for commit header built from type and scope patterns, groups are named to allow groups on patterns.
+type headerGrammar struct {
+	subject      *regexp.Regexp // Lenient, used to parse, type is matched in any case to report it on type-enum rule.
+	conventional *regexp.Regexp // Strict, used to validate header format.
+	fix          *regexp.Regexp // Accepts spaces around separators, used to suggest fixes.
+}
+
+func compileHeaderGrammar(cfg CommitMessageConfig) (headerGrammar, error) {
+	typePattern, scopePattern, parseScopePattern := defaultTypePattern, defaultScopePattern, ".*"
+	if cfg.TypePattern != "" {
+		typePattern = cfg.TypePattern
+	}
+	if cfg.Scope.Pattern != "" {
+		scopePattern, parseScopePattern = cfg.Scope.Pattern, cfg.Scope.Pattern
+	}
+	if _, err := regexp.Compile(typePattern); err != nil {
+		return headerGrammar{}, fmt.Errorf("invalid regex on type-pattern %s, error: %s", typePattern, err.Error())
+	}
+	if _, err := regexp.Compile(scopePattern); err != nil {
+		return headerGrammar{}, fmt.Errorf("invalid regex on scope pattern %s, error: %s", scopePattern, err.Error())
+	}
+
+	conventionalTypePattern := typePattern
+	if cfg.CaseInsensitiveTypes {
+		conventionalTypePattern = "(?i:" + typePattern + ")"
+	}
+	subject, err := regexp.Compile(fmt.Sprintf(`(?P<type>(?i:%s))(\((?P<scope>%s)\))?(?P<breaking>!)?: (?P<description>.*)`, typePattern, parseScopePattern))
+	if err != nil {
+		return headerGrammar{}, fmt.Errorf("invalid header grammar, error: %s", err.Error())
+	}
+	conventional, err := regexp.Compile(fmt.Sprintf(`^(?:%s)(\((?:%s)\))?!?: .+$`, conventionalTypePattern, scopePattern))
+	if err != nil {
+		return headerGrammar{}, fmt.Errorf("invalid header grammar, error: %s", err.Error())
+	}
+	fix, err := regexp.Compile(fmt.Sprintf(`^\s*(?P<type>(?i:%s))\s*(?:\(\s*(?P<scope>[^()]*?)\s*\))?\s*(?P<breaking>!)?\s*:\s*(?P<description>\S.*?)\s*$`, typePattern))
+	if err != nil {
+		return headerGrammar{}, fmt.Errorf("invalid header grammar, error: %s", err.Error())
+	}
+	return headerGrammar{subject: subject, conventional: conventional, fix: fix}, nil
+}
+
+// groupIndex start and end byte index of a named group from regexp index result, -1 if group did not match.
+func groupIndex(regex *regexp.Regexp, index []int, name string) (int, int) {
+	i := regex.SubexpIndex(name)
+	return index[2*i], index[2*i+1]
+}
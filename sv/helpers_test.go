@@ -27,6 +27,13 @@ func commitlog(ctype string, metadata map[string]string, author string) GitCommi
 	}
 }
 
+func nonConventionalCommitlog(author string) GitCommitLog {
+	return GitCommitLog{
+		Message:    NewNonConventionalCommitMessage("subject text", ""),
+		AuthorName: author,
+	}
+}
+
 func releaseNote(version *semver.Version, tag string, date time.Time, sections []ReleaseNoteSection, authorsNames map[string]struct{}) ReleaseNote {
 	return ReleaseNote{
 		Version:      version,
@@ -2,6 +2,7 @@ package sv
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -12,16 +13,29 @@ const (
 	breakingChangeMetadataKey = "breaking-change"
 	issueMetadataKey          = "issue"
 	messageRegexGroupName     = "header"
+	metadataValuesSeparator   = ", "
+	defaultTypePattern        = "[a-z]+"
+	defaultScopePattern       = ".+"
 )
 
+var descriptionRegex = regexp.MustCompile("^[a-z]+.*$")
+
+// ErrNonConventionalMessage is returned, wrapped, when a message could not be parsed as conventional commit.
+var ErrNonConventionalMessage = errors.New("non-conventional commit message")
+
 // CommitMessage is a message using conventional commits.
 type CommitMessage struct {
-	Type             string            `json:"type,omitempty"`
-	Scope            string            `json:"scope,omitempty"`
-	Description      string            `json:"description,omitempty"`
-	Body             string            `json:"body,omitempty"`
-	IsBreakingChange bool              `json:"isBreakingChange,omitempty"`
-	Metadata         map[string]string `json:"metadata,omitempty"`
+	Type             string              `json:"type,omitempty"`
+	OriginalType     string              `json:"originalType,omitempty"` // Type before aliases and case normalization, empty if type was not changed.
+	Scope            string              `json:"scope,omitempty"`
+	Scopes           []string            `json:"scopes,omitempty"` // Scope split by scope separator, a single scope if separator is not defined.
+	Description      string              `json:"description,omitempty"`
+	Body             string              `json:"body,omitempty"`
+	IsBreakingChange bool                `json:"isBreakingChange,omitempty"`
+	NonConventional  bool                `json:"nonConventional,omitempty"`
+	Metadata         map[string]string   `json:"metadata,omitempty"`       // Metadata values joined by ", ", breaking change keeps only the first one.
+	MetadataValues   map[string][]string `json:"metadataValues,omitempty"` // All values of each metadata key.
+	Footers          []CommitFooter      `json:"footers,omitempty"`        // All footers in the same order as message.
 }
 
 // NewCommitMessage commit message constructor.
@@ -36,11 +50,32 @@ func NewCommitMessage(ctype, scope, description, body, issue, breakingChanges st
 	return CommitMessage{Type: ctype, Scope: scope, Description: description, Body: body, IsBreakingChange: breakingChanges != "", Metadata: metadata}
 }
 
-// Issue return issue from metadata.
+// NewNonConventionalCommitMessage constructor for messages that does not follow conventional commits, subject is used as description.
+func NewNonConventionalCommitMessage(subject, body string) CommitMessage {
+	return CommitMessage{Description: subject, Body: body, NonConventional: true, Metadata: make(map[string]string)}
+}
+
+// Issue return issue from metadata, multiple issues are joined by ", ".
 func (m CommitMessage) Issue() string {
 	return m.Metadata[issueMetadataKey]
 }
 
+// Issues return all issues from metadata.
+func (m CommitMessage) Issues() []string {
+	return m.Values(issueMetadataKey)
+}
+
+// Values return all values of a metadata key.
+func (m CommitMessage) Values(key string) []string {
+	if values, found := m.MetadataValues[key]; found {
+		return values
+	}
+	if value := m.Metadata[key]; value != "" {
+		return []string{value}
+	}
+	return nil
+}
+
 // BreakingMessage return breaking change message from metadata.
 func (m CommitMessage) BreakingMessage() string {
 	return m.Metadata[breakingChangeMetadataKey]
@@ -53,24 +88,47 @@ type MessageProcessor interface {
 	ValidateType(ctype string) error
 	ValidateScope(scope string) error
 	ValidateDescription(description string) error
+	Lint(message string) ([]RuleViolation, error)
+	Fix(message string) (string, error)
 	Enhance(branch string, message string) (string, error)
 	IssueID(branch string) (string, error)
 	Format(msg CommitMessage) (string, string, string)
 	Parse(subject, body string) (CommitMessage, error)
 }
 
-// NewMessageProcessor MessageProcessorImpl constructor.
+// NewMessageProcessor MessageProcessorImpl constructor, regexes from config are compiled once, invalid ones are reported when used.
 func NewMessageProcessor(mcfg CommitMessageConfig, bcfg BranchesConfig) *MessageProcessorImpl {
-	return &MessageProcessorImpl{
+	p := &MessageProcessorImpl{
 		messageCfg:  mcfg,
 		branchesCfg: bcfg,
 	}
+
+	p.grammar, p.grammarErr = compileHeaderGrammar(mcfg)
+	if mcfg.HeaderSelector != "" {
+		p.headerSelectorRegex, p.headerSelectorErr = compileHeaderSelector(mcfg.HeaderSelector)
+	}
+	if !bcfg.DisableIssue && mcfg.Issue.Regex != "" {
+		rstr := fmt.Sprintf("^%s(%s)%s$", bcfg.Prefix, mcfg.Issue.Regex, bcfg.Suffix)
+		if p.branchIssueRegex, p.branchIssueErr = regexp.Compile(rstr); p.branchIssueErr != nil {
+			p.branchIssueErr = fmt.Errorf("could not compile issue regex: %s, error: %v", rstr, p.branchIssueErr.Error())
+		}
+	}
+	p.rules, p.rulesErr = compileRules(mcfg.Rules)
+	return p
 }
 
 // MessageProcessorImpl process validate message hook.
 type MessageProcessorImpl struct {
-	messageCfg  CommitMessageConfig
-	branchesCfg BranchesConfig
+	messageCfg          CommitMessageConfig
+	branchesCfg         BranchesConfig
+	grammar             headerGrammar
+	grammarErr          error
+	headerSelectorRegex *regexp.Regexp
+	headerSelectorErr   error
+	branchIssueRegex    *regexp.Regexp
+	branchIssueErr      error
+	rules               map[string]CommitMessageRuleConfig
+	rulesErr            error
 }
 
 // SkipBranch check if branch should be ignored.
@@ -78,61 +136,89 @@ func (p MessageProcessorImpl) SkipBranch(branch string, detached bool) bool {
 	return contains(branch, p.branchesCfg.Skip) || (p.branchesCfg.SkipDetached != nil && *p.branchesCfg.SkipDetached && detached)
 }
 
-// Validate commit message.
+// Validate commit message, return the first violation of a rule with error severity.
 func (p MessageProcessorImpl) Validate(message string) error {
-	subject, body := splitCommitMessageContent(message)
-	msg, parseErr := p.Parse(subject, body)
-
-	if parseErr != nil {
-		return parseErr
-	}
-
-	if !regexp.MustCompile(`^[a-z+]+(\(.+\))?!?: .+$`).MatchString(subject) {
-		return fmt.Errorf("subject [%s] should be valid according with conventional commits", subject)
-	}
-
-	if err := p.ValidateType(msg.Type); err != nil {
-		return err
-	}
-
-	if err := p.ValidateScope(msg.Scope); err != nil {
+	violations, err := p.Lint(message)
+	if err != nil {
 		return err
 	}
-
-	if err := p.ValidateDescription(msg.Description); err != nil {
-		return err
+	for _, v := range violations {
+		if v.Severity == RuleSeverityError {
+			return v
+		}
 	}
-
 	return nil
 }
 
-// ValidateType check if commit type is valid.
+// ValidateType check if commit type is valid, nil if type-enum rule is not an error.
 func (p MessageProcessorImpl) ValidateType(ctype string) error {
-	if ctype == "" || !contains(ctype, p.messageCfg.Types) {
-		return fmt.Errorf("message type should be one of [%v]", strings.Join(p.messageCfg.Types, ", "))
+	if !p.isErrorRule(RuleTypeEnum) {
+		return nil
+	}
+	return p.validateType(ctype)
+}
+
+func (p MessageProcessorImpl) validateType(ctype string) error {
+	if ctype = p.normalizeType(ctype); ctype == "" || !contains(ctype, p.messageCfg.Types) {
+		return RuleViolation{Rule: RuleTypeEnum, Severity: RuleSeverityError, Segment: SegmentType, Message: fmt.Sprintf("message type should be one of [%v]", strings.Join(p.messageCfg.Types, ", "))}
 	}
 	return nil
 }
 
-// ValidateScope check if commit scope is valid.
+// ValidateScope check if commit scope is valid, nil if scope-enum rule is not an error.
 func (p MessageProcessorImpl) ValidateScope(scope string) error {
-	if len(p.messageCfg.Scope.Values) > 0 && !contains(scope, p.messageCfg.Scope.Values) {
-		return fmt.Errorf("message scope should one of [%v]", strings.Join(p.messageCfg.Scope.Values, ", "))
+	if !p.isErrorRule(RuleScopeEnum) {
+		return nil
+	}
+	return p.validateScope(scope)
+}
+
+// validateScope check each scope if scope separator is defined.
+func (p MessageProcessorImpl) validateScope(scope string) error {
+	if len(p.messageCfg.Scope.Values) == 0 {
+		return nil
+	}
+	scopes := p.splitScopes(scope)
+	if len(scopes) == 0 {
+		scopes = []string{""}
+	}
+	for _, s := range scopes {
+		if !contains(s, p.messageCfg.Scope.Values) {
+			return RuleViolation{Rule: RuleScopeEnum, Severity: RuleSeverityError, Segment: SegmentScope, Message: fmt.Sprintf("message scope should one of [%v]", strings.Join(p.messageCfg.Scope.Values, ", "))}
+		}
 	}
 	return nil
 }
 
-// ValidateDescription check if commit description is valid.
+// ValidateDescription check if commit description is valid, nil if subject-case rule is not an error.
 func (p MessageProcessorImpl) ValidateDescription(description string) error {
-	if !regexp.MustCompile("^[a-z]+.*$").MatchString(description) {
-		return fmt.Errorf("description [%s] should begins with lowercase letter", description)
+	if !p.isErrorRule(RuleSubjectCase) {
+		return nil
+	}
+	return validateDescriptionCase(p.rules[RuleSubjectCase].Case, description)
+}
+
+func validateDescriptionCase(descriptionCase, description string) error {
+	if descriptionCase == CaseSentence {
+		if !sentenceCaseRegex.MatchString(description) {
+			return RuleViolation{Rule: RuleSubjectCase, Severity: RuleSeverityError, Segment: SegmentDescription, Message: fmt.Sprintf("description [%s] should begins with uppercase letter", description)}
+		}
+		return nil
+	}
+	if !descriptionRegex.MatchString(description) {
+		return RuleViolation{Rule: RuleSubjectCase, Severity: RuleSeverityError, Segment: SegmentDescription, Message: fmt.Sprintf("description [%s] should begins with lowercase letter", description)}
 	}
 	return nil
 }
 
-// Enhance add metadata on commit message.
+func (p MessageProcessorImpl) isErrorRule(id string) bool {
+	return p.rulesErr == nil && p.rules[id].Severity == RuleSeverityError && len(p.rules[id].Types) == 0
+}
+
+// Enhance add metadata on commit message, nothing is added if message already has any issue.
 func (p MessageProcessorImpl) Enhance(branch string, message string) (string, error) {
-	if p.branchesCfg.DisableIssue || p.messageCfg.IssueFooterConfig().Key == "" || hasIssueID(message, p.messageCfg.IssueFooterConfig()) {
+	issueCfg := p.messageCfg.IssueFooterConfig()
+	if p.branchesCfg.DisableIssue || issueCfg.Key == "" || hasIssueID(message, issueCfg) {
 		return "", nil // enhance disabled
 	}
 
@@ -144,7 +230,7 @@ func (p MessageProcessorImpl) Enhance(branch string, message string) (string, er
 		return "", fmt.Errorf("could not find issue id using configured regex")
 	}
 
-	footer := formatIssueFooter(p.messageCfg.IssueFooterConfig(), issue)
+	footer := formatIssueFooter(issueCfg, issue)
 	if !hasFooter(message) {
 		return "\n" + footer, nil
 	}
@@ -164,17 +250,14 @@ func formatIssueFooter(cfg CommitMessageFooterConfig, issue string) string {
 
 // IssueID try to extract issue id from branch, return empty if not found.
 func (p MessageProcessorImpl) IssueID(branch string) (string, error) {
-	if p.branchesCfg.DisableIssue || p.messageCfg.Issue.Regex == "" {
-		return "", nil
+	if p.branchIssueErr != nil {
+		return "", p.branchIssueErr
 	}
-
-	rstr := fmt.Sprintf("^%s(%s)%s$", p.branchesCfg.Prefix, p.messageCfg.Issue.Regex, p.branchesCfg.Suffix)
-	r, err := regexp.Compile(rstr)
-	if err != nil {
-		return "", fmt.Errorf("could not compile issue regex: %s, error: %v", rstr, err.Error())
+	if p.branchIssueRegex == nil {
+		return "", nil
 	}
 
-	groups := r.FindStringSubmatch(branch)
+	groups := p.branchIssueRegex.FindStringSubmatch(branch)
 	if len(groups) != 4 {
 		return "", nil
 	}
@@ -188,6 +271,9 @@ func (p MessageProcessorImpl) Format(msg CommitMessage) (string, string, string)
 	if msg.Scope != "" {
 		header.WriteString("(" + msg.Scope + ")")
 	}
+	if msg.IsBreakingChange && msg.BreakingMessage() == "" {
+		header.WriteString("!")
+	}
 	header.WriteString(": ")
 	header.WriteString(msg.Description)
 
@@ -195,18 +281,20 @@ func (p MessageProcessorImpl) Format(msg CommitMessage) (string, string, string)
 	if msg.BreakingMessage() != "" {
 		footer.WriteString(fmt.Sprintf("%s: %s", breakingChangeFooterKey, msg.BreakingMessage()))
 	}
-	if issue, exists := msg.Metadata[issueMetadataKey]; exists && p.messageCfg.IssueFooterConfig().Key != "" {
-		if footer.Len() > 0 {
-			footer.WriteString("\n")
+	if issueCfg := p.messageCfg.IssueFooterConfig(); issueCfg.Key != "" {
+		for _, issue := range msg.Issues() {
+			if footer.Len() > 0 {
+				footer.WriteString("\n")
+			}
+			footer.WriteString(formatIssueFooter(issueCfg, issue))
 		}
-		footer.WriteString(formatIssueFooter(p.messageCfg.IssueFooterConfig(), issue))
 	}
 
 	return header.String(), msg.Body, footer.String()
 }
 
 func removeCarriage(commit string) string {
-	return regexp.MustCompile(`\r`).ReplaceAllString(commit, "")
+	return strings.ReplaceAll(commit, "\r", "")
 }
 
 // Parse a commit message.
@@ -218,106 +306,146 @@ func (p MessageProcessorImpl) Parse(subject, body string) (CommitMessage, error)
 		return CommitMessage{}, err
 	}
 
-	commitType, scope, description, hasBreakingChange := parseSubjectMessage(preparedSubject)
+	commitType, scope, description, hasBreakingChange := p.parseSubjectMessage(preparedSubject)
 
+	footers := parseFooters(commitBody)
 	metadata := make(map[string]string)
+	var metadataValues map[string][]string
+	addMetadata := func(key string, values []string, value string) {
+		if metadataValues == nil {
+			metadataValues = make(map[string][]string)
+		}
+		metadata[key] = value
+		metadataValues[key] = values
+	}
+
+	var originalType string
+	if normalizedType := p.normalizeType(commitType); normalizedType != commitType {
+		originalType, commitType = commitType, normalizedType
+	}
 	for key, mdCfg := range p.messageCfg.Footer {
-		if mdCfg.Key != "" {
-			prefixes := append([]string{mdCfg.Key}, mdCfg.KeySynonyms...)
-			for _, prefix := range prefixes {
-				if tagValue := extractFooterMetadata(prefix, commitBody, mdCfg.UseHash); tagValue != "" {
-					metadata[key] = tagValue
-					break
-				}
-			}
+		if values := footerValues(footers, mdCfg); len(values) > 0 {
+			addMetadata(key, values, strings.Join(values, metadataValuesSeparator))
+		}
+	}
+	var breakingChanges []string
+	for _, footer := range footers {
+		if footer.IsBreakingChange() && footer.Value != "" {
+			breakingChanges = append(breakingChanges, footer.Value)
 		}
 	}
-	if tagValue := extractFooterMetadata(breakingChangeFooterKey, commitBody, false); tagValue != "" {
-		metadata[breakingChangeMetadataKey] = tagValue
+	if len(breakingChanges) > 0 {
+		addMetadata(breakingChangeMetadataKey, breakingChanges, breakingChanges[0])
 		hasBreakingChange = true
 	}
 
 	return CommitMessage{
 		Type:             commitType,
+		OriginalType:     originalType,
 		Scope:            scope,
+		Scopes:           p.splitScopes(scope),
 		Description:      description,
 		Body:             commitBody,
 		IsBreakingChange: hasBreakingChange,
 		Metadata:         metadata,
+		MetadataValues:   metadataValues,
+		Footers:          footers,
 	}, nil
 }
 
-func (p MessageProcessorImpl) prepareHeader(header string) (string, error) {
-	if p.messageCfg.HeaderSelector == "" {
-		return header, nil
+// normalizeType apply type aliases and lower case type if types are case insensitive.
+func (p MessageProcessorImpl) normalizeType(ctype string) string {
+	if p.messageCfg.CaseInsensitiveTypes {
+		ctype = strings.ToLower(ctype)
 	}
+	if target, exists := p.messageCfg.TypeAliases[ctype]; exists {
+		return target
+	}
+	if p.messageCfg.CaseInsensitiveTypes {
+		for alias, target := range p.messageCfg.TypeAliases {
+			if strings.EqualFold(alias, ctype) {
+				return target
+			}
+		}
+	}
+	return ctype
+}
 
-	regex, err := regexp.Compile(p.messageCfg.HeaderSelector)
-	if err != nil {
-		return "", fmt.Errorf("invalid regex on header-selector %s, error: %s", p.messageCfg.HeaderSelector, err.Error())
+// splitScopes split scope using scope separator, empty scopes are ignored.
+func (p MessageProcessorImpl) splitScopes(scope string) []string {
+	if strings.TrimSpace(scope) == "" {
+		return nil
+	}
+	if p.messageCfg.Scope.Separator == "" {
+		return []string{scope}
+	}
+	var scopes []string
+	for _, s := range strings.Split(scope, p.messageCfg.Scope.Separator) {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
 	}
+	return scopes
+}
+
+// isConventionalHeader check header format, type can be upper case if types are case insensitive.
+func (p MessageProcessorImpl) isConventionalHeader(header string) bool {
+	return p.grammar.conventional.MatchString(header)
+}
 
-	index := regex.SubexpIndex(messageRegexGroupName)
-	if index < 0 {
-		return "", fmt.Errorf("could not find %s regex group on header-selector regex", messageRegexGroupName)
+func (p MessageProcessorImpl) prepareHeader(header string) (string, error) {
+	if p.grammarErr != nil {
+		return "", p.grammarErr
+	}
+	if p.headerSelectorErr != nil {
+		return "", p.headerSelectorErr
+	}
+	if p.headerSelectorRegex == nil {
+		return header, nil
 	}
 
-	match := regex.FindStringSubmatch(header)
+	index := p.headerSelectorRegex.SubexpIndex(messageRegexGroupName)
+	match := p.headerSelectorRegex.FindStringSubmatch(header)
 
 	if match == nil || len(match) < index {
-		return "", fmt.Errorf("could not find %s regex group in match result for '%s'", messageRegexGroupName, header)
+		return "", fmt.Errorf("%w, could not find %s regex group in match result for '%s'", ErrNonConventionalMessage, messageRegexGroupName, header)
 	}
 
 	return match[index], nil
 }
 
-func parseSubjectMessage(message string) (string, string, string, bool) {
-	regex := regexp.MustCompile(`([a-z]+)(\((.*)\))?(!)?: (.*)`)
-	result := regex.FindStringSubmatch(message)
-	if len(result) != 6 {
-		return "", "", message, false
+func compileHeaderSelector(selector string) (*regexp.Regexp, error) {
+	regex, err := regexp.Compile(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex on header-selector %s, error: %s", selector, err.Error())
+	}
+	if regex.SubexpIndex(messageRegexGroupName) < 0 {
+		return nil, fmt.Errorf("could not find %s regex group on header-selector regex", messageRegexGroupName)
 	}
-	return result[1], result[3], strings.TrimSpace(result[5]), result[4] == "!"
+	return regex, nil
 }
 
-func extractFooterMetadata(key, text string, useHash bool) string {
-	var regex *regexp.Regexp
-	if useHash {
-		regex = regexp.MustCompile(key + " (#.*)")
-	} else {
-		regex = regexp.MustCompile(key + ": (.*)")
+func (p MessageProcessorImpl) parseSubjectMessage(message string) (string, string, string, bool) {
+	result := p.grammar.subject.FindStringSubmatch(message)
+	if result == nil {
+		return "", "", message, false
 	}
+	group := func(name string) string { return result[p.grammar.subject.SubexpIndex(name)] }
+	return group(typeGroupName), group(scopeGroupName), strings.TrimSpace(group(descriptionGroupName)), group(breakingGroupName) == "!"
+}
 
-	result := regex.FindStringSubmatch(text)
-	if len(result) < 2 {
-		return ""
-	}
-	return result[1]
+// messageFooters parse footers from a commit message file content, comments are ignored.
+func messageFooters(message string) []CommitFooter {
+	_, body := splitCommitMessageContent(stripCommentLines(removeCarriage(message)))
+	return parseFooters(body)
 }
 
 func hasFooter(message string) bool {
-	r := regexp.MustCompile("^[a-zA-Z-]+: .*|^[a-zA-Z-]+ #.*|^" + breakingChangeFooterKey + ": .*")
-
-	scanner := bufio.NewScanner(strings.NewReader(message))
-	lines := 0
-	for scanner.Scan() {
-		if lines > 0 && r.MatchString(scanner.Text()) {
-			return true
-		}
-		lines++
-	}
-
-	return false
+	return len(messageFooters(message)) > 0
 }
 
 func hasIssueID(message string, issueConfig CommitMessageFooterConfig) bool {
-	var r *regexp.Regexp
-	if issueConfig.UseHash {
-		r = regexp.MustCompile(fmt.Sprintf("(?m)^%s #.+$", issueConfig.Key))
-	} else {
-		r = regexp.MustCompile(fmt.Sprintf("(?m)^%s: .+$", issueConfig.Key))
-	}
-	return r.MatchString(message)
+	return len(footerValues(messageFooters(message), issueConfig)) > 0
 }
 
 func contains(value string, content []string) bool {
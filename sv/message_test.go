@@ -34,6 +34,35 @@ var ccfgGitIssue = CommitMessageConfig{
 	Issue: CommitMessageIssueConfig{Regex: "#?[0-9]+"},
 }
 
+var ccfgMultiValue = CommitMessageConfig{
+	Types: []string{"feat", "fix"},
+	Scope: CommitMessageScopeConfig{},
+	Footer: map[string]CommitMessageFooterConfig{
+		"issue":      {Key: "jira", KeySynonyms: []string{"Jira"}, ValueSeparator: ","},
+		"co-authors": {Key: "Co-authored-by"},
+	},
+	Issue: CommitMessageIssueConfig{Regex: "[A-Z]+-[0-9]+"},
+}
+
+var ccfgTypeAliases = CommitMessageConfig{
+	Types:                []string{"feat", "fix"},
+	TypeAliases:          map[string]string{"feature": "feat", "bugfix": "fix"},
+	CaseInsensitiveTypes: true,
+}
+
+var ccfgTypeAliasesFooter = CommitMessageConfig{
+	Types:       []string{"feat", "fix"},
+	TypeAliases: map[string]string{"feature": "feat"},
+	Footer:      map[string]CommitMessageFooterConfig{"original-type": {Key: "Original-Type"}},
+}
+
+var ccfgHeaderGrammar = CommitMessageConfig{
+	Types:                []string{"feat", "fix", "i18n", "deps"},
+	TypePattern:          "[a-z0-9]+",
+	CaseInsensitiveTypes: true,
+	Scope:                CommitMessageScopeConfig{Values: []string{"", "api", "ui"}, Separator: ","},
+}
+
 var ccfgEmptyIssue = CommitMessageConfig{
 	Types: []string{"feat", "fix"},
 	Scope: CommitMessageScopeConfig{},
@@ -163,6 +192,19 @@ func TestMessageProcessorImpl_Validate(t *testing.T) {
 
 		{"support ! for breaking change", ccfg, "feat!: add something", false},
 		{"support ! with scope for breaking change", ccfg, "feat(scope)!: add something", false},
+		{"type alias", ccfgTypeAliases, "feature: add something", false},
+		{"case insensitive type", ccfgTypeAliases, "Feat(scope): add something", false},
+		{"case sensitive type", ccfg, "Feat: add something", true},
+		{"type alias not configured", ccfg, "feature: add something", true},
+		{"type with number", ccfg, "i18n: add something", true},
+		{"type pattern with number", ccfgHeaderGrammar, "i18n: add something", false},
+		{"type pattern case insensitive", ccfgHeaderGrammar, "Deps: update something", false},
+		{"multiple scopes", ccfgHeaderGrammar, "feat(api, ui): add something", false},
+		{"multiple scopes with invalid scope", ccfgHeaderGrammar, "feat(api,db): add something", true},
+		{"multiple scopes without separator", ccfgWithScope, "feat(scope,scope): add something", true},
+		{"scope pattern", CommitMessageConfig{Types: []string{"feat"}, Scope: CommitMessageScopeConfig{Pattern: "[a-z]+"}}, "feat(scope): add something", false},
+		{"invalid scope pattern", CommitMessageConfig{Types: []string{"feat"}, Scope: CommitMessageScopeConfig{Pattern: "[a-z]+"}}, "feat(scope-1): add something", true},
+		{"invalid type pattern regex", CommitMessageConfig{Types: []string{"feat"}, TypePattern: "[a-z"}, "feat: add something", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -205,6 +247,9 @@ func TestMessageProcessorImpl_ValidateScope(t *testing.T) {
 		{"any scope", ccfg, "aaa", false},
 		{"valid scope with scope list", ccfgWithScope, "scope", false},
 		{"invalid scope with scope list", ccfgWithScope, "aaa", true},
+		{"valid scopes with separator", ccfgHeaderGrammar, "api,ui", false},
+		{"invalid scopes with separator", ccfgHeaderGrammar, "api,aaa", true},
+		{"empty scope with separator", ccfgHeaderGrammar, "", false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -253,6 +298,10 @@ func TestMessageProcessorImpl_Enhance(t *testing.T) {
 		{"issue on branch name with prefix", ccfg, "feature/JIRA-123", "fix: fix something", "\njira: JIRA-123", false},
 		{"with footer", ccfg, "JIRA-123", fullMessage, "jira: JIRA-123", false},
 		{"with issue on footer", ccfg, "JIRA-123", fullMessageWithJira, "", false},
+		{"with different issue on footer", ccfg, "JIRA-123", "fix: fix something\n\njira: JIRA-456", "", false},
+		{"with issue synonym on footer", ccfg, "JIRA-123", "fix: fix something\n\nJira: JIRA-456", "", false},
+		{"with issue on body", ccfg, "JIRA-123", "fix: fix something\n\nsee jira: JIRA-456 for details", "\njira: JIRA-123", false},
+		{"with footer and comments", ccfg, "JIRA-123", fullMessage + "\n\n# Please enter the commit message for your changes.\n#\n", "jira: JIRA-123", false},
 		{"issue on branch name with prefix and description", ccfg, "feature/JIRA-123-some-description", "fix: fix something", "\njira: JIRA-123", false},
 		{"no issue on branch name", ccfg, "branch", "fix: fix something", "", true},
 		{"unexpected branch name", ccfg, "feature /JIRA-123", "fix: fix something", "", true},
@@ -394,6 +443,21 @@ var hashMetadataBody = `some descriptions
 Jira: JIRA-999
 Refs #123`
 
+var hashMetadataFooters = []CommitFooter{{"Jira", ": ", "JIRA-999"}, {"Refs", " #", "123"}}
+
+var multiValueBody = `some descriptions
+
+jira: JIRA-1, JIRA-2
+Co-authored-by: a <a@example.com>
+Jira: JIRA-3,JIRA-1
+Co-authored-by: b <b@example.com>`
+
+var multilineFooterBody = `some descriptions
+
+BREAKING-CHANGE: config file
+  was renamed
+Refs #123`
+
 func TestMessageProcessorImpl_Parse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -403,15 +467,27 @@ func TestMessageProcessorImpl_Parse(t *testing.T) {
 		want    CommitMessage
 	}{
 		{"simple message", ccfg, "feat: something awesome", "", CommitMessage{Type: "feat", Scope: "", Description: "something awesome", Body: "", IsBreakingChange: false, Metadata: map[string]string{}}},
-		{"message with scope", ccfg, "feat(scope): something awesome", "", CommitMessage{Type: "feat", Scope: "scope", Description: "something awesome", Body: "", IsBreakingChange: false, Metadata: map[string]string{}}},
+		{"message with scope", ccfg, "feat(scope): something awesome", "", CommitMessage{Type: "feat", Scope: "scope", Scopes: []string{"scope"}, Description: "something awesome", Body: "", IsBreakingChange: false, Metadata: map[string]string{}}},
 		{"unmapped type", ccfg, "unkn: something unknown", "", CommitMessage{Type: "unkn", Scope: "", Description: "something unknown", Body: "", IsBreakingChange: false, Metadata: map[string]string{}}},
-		{"jira and breaking change metadata", ccfg, "feat: something new", completeBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: completeBody, IsBreakingChange: true, Metadata: map[string]string{issueMetadataKey: "JIRA-123", breakingChangeMetadataKey: "this change breaks everything"}}},
-		{"jira only metadata", ccfg, "feat: something new", issueOnlyBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: issueOnlyBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-456"}}},
-		{"jira synonyms metadata", ccfg, "feat: something new", issueSynonymsBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: issueSynonymsBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-789"}}},
+		{"jira and breaking change metadata", ccfg, "feat: something new", completeBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: completeBody, IsBreakingChange: true, Metadata: map[string]string{issueMetadataKey: "JIRA-123", breakingChangeMetadataKey: "this change breaks everything"}, MetadataValues: map[string][]string{issueMetadataKey: {"JIRA-123"}, breakingChangeMetadataKey: {"this change breaks everything"}}, Footers: []CommitFooter{{"jira", ": ", "JIRA-123"}, {"BREAKING CHANGE", ": ", "this change breaks everything"}}}},
+		{"jira only metadata", ccfg, "feat: something new", issueOnlyBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: issueOnlyBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-456"}, MetadataValues: map[string][]string{issueMetadataKey: {"JIRA-456"}}, Footers: []CommitFooter{{"jira", ": ", "JIRA-456"}}}},
+		{"jira synonyms metadata", ccfg, "feat: something new", issueSynonymsBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: issueSynonymsBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-789"}, MetadataValues: map[string][]string{issueMetadataKey: {"JIRA-789"}}, Footers: []CommitFooter{{"Jira", ": ", "JIRA-789"}}}},
 		{"breaking change with exclamation mark", ccfg, "feat!: something new", "", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "", IsBreakingChange: true, Metadata: map[string]string{}}},
-		{"hash metadata", ccfg, "feat: something new", hashMetadataBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: hashMetadataBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-999", "refs": "#123"}}},
-		{"empty issue cfg", ccfgEmptyIssue, "feat: something new", hashMetadataBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: hashMetadataBody, IsBreakingChange: false, Metadata: map[string]string{}}},
-		{"carriage return on body", ccfg, "feat: something new", bodyWithCarriage, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: expectedBodyWithCarriage, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-123"}}},
+		{"hash metadata", ccfg, "feat: something new", hashMetadataBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: hashMetadataBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-999", "refs": "#123"}, MetadataValues: map[string][]string{issueMetadataKey: {"JIRA-999"}, "refs": {"#123"}}, Footers: hashMetadataFooters}},
+		{"empty issue cfg", ccfgEmptyIssue, "feat: something new", hashMetadataBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: hashMetadataBody, IsBreakingChange: false, Metadata: map[string]string{}, Footers: hashMetadataFooters}},
+		{"carriage return on body", ccfg, "feat: something new", bodyWithCarriage, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: expectedBodyWithCarriage, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-123"}, MetadataValues: map[string][]string{issueMetadataKey: {"JIRA-123"}}, Footers: []CommitFooter{{"jira", ": ", "JIRA-123"}}}},
+		{"breaking change synonym and multiline footer", ccfg, "feat: something new", multilineFooterBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: multilineFooterBody, IsBreakingChange: true, Metadata: map[string]string{breakingChangeMetadataKey: "config file\n  was renamed", "refs": "#123"}, MetadataValues: map[string][]string{breakingChangeMetadataKey: {"config file\n  was renamed"}, "refs": {"#123"}}, Footers: []CommitFooter{{"BREAKING-CHANGE", ": ", "config file\n  was renamed"}, {"Refs", " #", "123"}}}},
+		{"multiple values", ccfgMultiValue, "feat: something new", multiValueBody, CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: multiValueBody, IsBreakingChange: false, Metadata: map[string]string{issueMetadataKey: "JIRA-1, JIRA-2, JIRA-3", "co-authors": "a <a@example.com>, b <b@example.com>"}, MetadataValues: map[string][]string{issueMetadataKey: {"JIRA-1", "JIRA-2", "JIRA-3"}, "co-authors": {"a <a@example.com>", "b <b@example.com>"}}, Footers: []CommitFooter{{"jira", ": ", "JIRA-1, JIRA-2"}, {"Co-authored-by", ": ", "a <a@example.com>"}, {"Jira", ": ", "JIRA-3,JIRA-1"}, {"Co-authored-by", ": ", "b <b@example.com>"}}}},
+		{"footer not on last paragraph", ccfg, "feat: something new", "jira: JIRA-123\n\nsome descriptions", CommitMessage{Type: "feat", Scope: "", Description: "something new", Body: "jira: JIRA-123\n\nsome descriptions", IsBreakingChange: false, Metadata: map[string]string{}}},
+		{"non-conventional message", ccfg, "Merge branch 'x'", "", CommitMessage{Type: "", Scope: "", Description: "Merge branch 'x'", Body: "", IsBreakingChange: false, Metadata: map[string]string{}}},
+		{"type alias", ccfgTypeAliases, "feature: something new", "", CommitMessage{Type: "feat", OriginalType: "feature", Description: "something new", Metadata: map[string]string{}}},
+		{"case insensitive type", ccfgTypeAliases, "Fix: something new", "", CommitMessage{Type: "fix", OriginalType: "Fix", Description: "something new", Metadata: map[string]string{}}},
+		{"case insensitive type alias", ccfgTypeAliases, "BugFix(scope): something new", "", CommitMessage{Type: "fix", OriginalType: "BugFix", Scope: "scope", Scopes: []string{"scope"}, Description: "something new", Metadata: map[string]string{}}},
+		{"type alias with footer using same key", ccfgTypeAliasesFooter, "feature: something new", "Original-Type: footer value", CommitMessage{Type: "feat", OriginalType: "feature", Description: "something new", Body: "Original-Type: footer value", Metadata: map[string]string{"original-type": "footer value"}, MetadataValues: map[string][]string{"original-type": {"footer value"}}, Footers: []CommitFooter{{"Original-Type", ": ", "footer value"}}}},
+		{"case sensitive type", ccfg, "Fix: something new", "", CommitMessage{Type: "Fix", Description: "something new", Metadata: map[string]string{}}},
+		{"type pattern with number", ccfgHeaderGrammar, "i18n(ui): something new", "", CommitMessage{Type: "i18n", Scope: "ui", Scopes: []string{"ui"}, Description: "something new", Metadata: map[string]string{}}},
+		{"multiple scopes", ccfgHeaderGrammar, "feat(api, ui): something new", "", CommitMessage{Type: "feat", Scope: "api, ui", Scopes: []string{"api", "ui"}, Description: "something new", Metadata: map[string]string{}}},
+		{"multiple scopes without separator", ccfg, "feat(api,ui): something new", "", CommitMessage{Type: "feat", Scope: "api,ui", Scopes: []string{"api,ui"}, Description: "something new", Metadata: map[string]string{}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -437,11 +513,13 @@ func TestMessageProcessorImpl_Format(t *testing.T) {
 		{"with issue using double hash", ccfgHash, NewCommitMessage("feat", "", "something", "", "#JIRA-123", ""), "feat: something", "", "jira #JIRA-123"},
 		{"with breaking change", ccfg, NewCommitMessage("feat", "", "something", "", "", "breaks"), "feat: something", "", "BREAKING CHANGE: breaks"},
 		{"with scope", ccfg, NewCommitMessage("feat", "scope", "something", "", "", ""), "feat(scope): something", "", ""},
+		{"with breaking change without message", ccfg, CommitMessage{Type: "feat", Scope: "scope", Description: "something", IsBreakingChange: true}, "feat(scope)!: something", "", ""},
 		{"with body", ccfg, NewCommitMessage("feat", "", "something", "body", "", ""), "feat: something", "body", ""},
 		{"with multiline body", ccfg, NewCommitMessage("feat", "", "something", multilineBody, "", ""), "feat: something", multilineBody, ""},
 		{"full message", ccfg, NewCommitMessage("feat", "scope", "something", multilineBody, "JIRA-123", "breaks"), "feat(scope): something", multilineBody, fullFooter},
 		{"config without issue key", ccfgEmptyIssue, NewCommitMessage("feat", "", "something", "", "JIRA-123", ""), "feat: something", "", ""},
 		{"with issue and issue prefix", ccfgGitIssue, NewCommitMessage("feat", "", "something", "", "123", ""), "feat: something", "", "issue: #123"},
+		{"with multiple issues", ccfgMultiValue, CommitMessage{Type: "feat", Description: "something", MetadataValues: map[string][]string{issueMetadataKey: {"JIRA-1", "JIRA-2"}}}, "feat: something", "", "jira: JIRA-1\njira: JIRA-2"},
 		{"with #issue and issue prefix", ccfgGitIssue, NewCommitMessage("feat", "", "something", "", "#123", ""), "feat: something", "", "issue: #123"},
 	}
 	for _, tt := range tests {
@@ -491,23 +569,27 @@ func Test_splitCommitMessageContent(t *testing.T) {
 	}
 }
 
-func Test_parseSubjectMessage(t *testing.T) {
+func TestMessageProcessorImpl_parseSubjectMessage(t *testing.T) {
 	tests := []struct {
 		name                  string
+		cfg                   CommitMessageConfig
 		message               string
 		wantType              string
 		wantScope             string
 		wantDescription       string
 		wantHasBreakingChange bool
 	}{
-		{"valid commit", "feat: something", "feat", "", "something", false},
-		{"valid commit with scope", "feat(scope): something", "feat", "scope", "something", false},
-		{"valid commit with breaking change", "feat(scope)!: something", "feat", "scope", "something", true},
-		{"missing description", "feat: ", "feat", "", "", false},
+		{"valid commit", ccfg, "feat: something", "feat", "", "something", false},
+		{"valid commit with scope", ccfg, "feat(scope): something", "feat", "scope", "something", false},
+		{"valid commit with breaking change", ccfg, "feat(scope)!: something", "feat", "scope", "something", true},
+		{"missing description", ccfg, "feat: ", "feat", "", "", false},
+		{"type pattern with number", ccfgHeaderGrammar, "i18n: something", "i18n", "", "something", false},
+		{"type pattern with groups", CommitMessageConfig{TypePattern: "(feat|fix)(-[a-z]+)?"}, "fix-ui(scope)!: something", "fix-ui", "scope", "something", true},
+		{"scope pattern with groups", CommitMessageConfig{Scope: CommitMessageScopeConfig{Pattern: "([a-z]+)(,[a-z]+)*"}}, "feat(api,ui)!: something", "feat", "api,ui", "something", true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctype, scope, description, hasBreakingChange := parseSubjectMessage(tt.message)
+			ctype, scope, description, hasBreakingChange := NewMessageProcessor(tt.cfg, newBranchCfg(false)).parseSubjectMessage(tt.message)
 			if ctype != tt.wantType {
 				t.Errorf("parseSubjectMessage() type got = %v, want %v", ctype, tt.wantType)
 			}
@@ -539,7 +621,7 @@ func Test_prepareHeader(t *testing.T) {
 		{"matching non-conventional with selector with group", "Merged PR (\\d+): (?P<header>.*)", "Merged PR 123: something", "something", false},
 		{"matching non-conventional with selector without group", "Merged PR (\\d+): (.*)", "Merged PR 123: something", "", true},
 		{"non-matching non-conventional with selector with group", "Merged PR (\\d+): (?P<header>.*)", "something", "", true},
-		{"matching non-conventional with invalid regex", "Merged PR (\\d+): (?<header>.*)", "Merged PR 123: something", "", true},
+		{"matching non-conventional with invalid regex", "Merged PR (\\d+): (?P<header>.*", "Merged PR 123: something", "", true}, // unbalanced group, (?<name>re) is valid since go 1.22
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -574,3 +656,15 @@ func Test_removeCarriage(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkMessageProcessorImpl_Parse(b *testing.B) {
+	p := NewMessageProcessor(newCommitMessageCfg("^Merged PR [0-9]+: (?P<header>.*)$"), newBranchCfg(false))
+	body := "some body\n\njira: JIRA-123\nRefs #456\nBREAKING CHANGE: breaking change message"
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.Parse("Merged PR 1: feat(scope): something new", body); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -27,9 +27,13 @@ func (p ReleaseNoteProcessorImpl) Create(version *semver.Version, tag string, da
 
 	sections := make(map[string]ReleaseNoteCommitsSection)
 	authors := make(map[string]struct{})
-	var breakingChanges []string
+	var breakingChanges []BreakingChange
+	var nonConventional []GitCommitLog
 	for _, commit := range commits {
 		authors[commit.AuthorName] = struct{}{}
+		if commit.Message.NonConventional {
+			nonConventional = append(nonConventional, commit)
+		}
 		if sectionCfg, exists := mapping[commit.Message.Type]; exists {
 			section, sexists := sections[sectionCfg.Name]
 			if !sexists {
@@ -38,32 +42,45 @@ func (p ReleaseNoteProcessorImpl) Create(version *semver.Version, tag string, da
 			section.Items = append(section.Items, commit)
 			sections[sectionCfg.Name] = section
 		}
-		if commit.Message.BreakingMessage() != "" {
-			// TODO: if no message found, should use description instead?
-			breakingChanges = append(breakingChanges, commit.Message.BreakingMessage())
-		}
+		breakingChanges = append(breakingChanges, commitBreakingChanges(commit)...)
 	}
 
 	var breakingChangeSection ReleaseNoteBreakingChangeSection
 	if bcCfg := p.cfg.sectionConfig(ReleaseNotesSectionTypeBreakingChanges); bcCfg != nil && len(breakingChanges) > 0 {
-		breakingChangeSection = ReleaseNoteBreakingChangeSection{Name: bcCfg.Name, Messages: breakingChanges}
+		messages := make([]string, len(breakingChanges))
+		for i, bc := range breakingChanges {
+			messages[i] = bc.Message
+		}
+		breakingChangeSection = ReleaseNoteBreakingChangeSection{Name: bcCfg.Name, Messages: messages, Items: breakingChanges}
+	}
+	var nonConventionalSection ReleaseNoteNonConventionalSection
+	if ncCfg := p.cfg.sectionConfig(ReleaseNotesSectionTypeNonConventional); ncCfg != nil && len(nonConventional) > 0 {
+		nonConventionalSection = ReleaseNoteNonConventionalSection{Name: ncCfg.Name, Items: nonConventional}
 	}
-	return ReleaseNote{Version: version, Tag: tag, Date: date.Truncate(time.Minute), Sections: p.toReleaseNoteSections(sections, breakingChangeSection), AuthorsNames: authors}
+	return ReleaseNote{Version: version, Tag: tag, Date: date.Truncate(time.Minute), Sections: p.toReleaseNoteSections(sections, breakingChangeSection, nonConventionalSection), AuthorsNames: authors}
 }
 
-func (p ReleaseNoteProcessorImpl) toReleaseNoteSections(commitSections map[string]ReleaseNoteCommitsSection, breakingChange ReleaseNoteBreakingChangeSection) []ReleaseNoteSection {
+func (p ReleaseNoteProcessorImpl) toReleaseNoteSections(commitSections map[string]ReleaseNoteCommitsSection, breakingChange ReleaseNoteBreakingChangeSection, nonConventional ReleaseNoteNonConventionalSection) []ReleaseNoteSection {
 	hasBreaking := 0
 	if breakingChange.Name != "" {
 		hasBreaking = 1
 	}
+	hasNonConventional := 0
+	if nonConventional.Name != "" {
+		hasNonConventional = 1
+	}
 
-	sections := make([]ReleaseNoteSection, len(commitSections)+hasBreaking)
+	sections := make([]ReleaseNoteSection, len(commitSections)+hasBreaking+hasNonConventional)
 	i := 0
 	for _, cfg := range p.cfg.Sections {
 		if cfg.SectionType == ReleaseNotesSectionTypeBreakingChanges && hasBreaking > 0 {
 			sections[i] = breakingChange
 			i++
 		}
+		if cfg.SectionType == ReleaseNotesSectionTypeNonConventional && hasNonConventional > 0 {
+			sections[i] = nonConventional
+			i++
+		}
 		if s, exists := commitSections[cfg.Name]; cfg.SectionType == ReleaseNotesSectionTypeCommits && exists {
 			sections[i] = s
 			i++
@@ -85,6 +102,23 @@ func commitSectionMapping(sections []ReleaseNotesSectionConfig) map[string]Relea
 	return mapping
 }
 
+// commitBreakingChanges breaking changes from commit footers, description is used if commit is marked with "!" without footer.
+func commitBreakingChanges(commit GitCommitLog) []BreakingChange {
+	if !commit.Message.IsBreakingChange {
+		return nil
+	}
+	messages := commit.Message.Values(breakingChangeMetadataKey)
+	if len(messages) == 0 {
+		messages = []string{commit.Message.Description}
+	}
+
+	result := make([]BreakingChange, len(messages))
+	for i, message := range messages {
+		result[i] = BreakingChange{Message: message, Hash: commit.Hash, Type: commit.Message.Type, Scope: commit.Message.Scope, Scopes: commit.Message.Scopes, Commit: commit}
+	}
+	return result
+}
+
 // ReleaseNote release note.
 type ReleaseNote struct {
 	Version      *semver.Version
@@ -104,6 +138,17 @@ type ReleaseNoteSection interface {
 type ReleaseNoteBreakingChangeSection struct {
 	Name     string
 	Messages []string
+	Items    []BreakingChange
+}
+
+// BreakingChange breaking change message and the commit that introduced it.
+type BreakingChange struct {
+	Message string // Breaking change footer, can have multiple lines, or commit description if commit has no footer.
+	Hash    string
+	Type    string
+	Scope   string
+	Scopes  []string
+	Commit  GitCommitLog
 }
 
 // SectionType section type.
@@ -116,6 +161,22 @@ func (s ReleaseNoteBreakingChangeSection) SectionName() string {
 	return s.Name
 }
 
+// ReleaseNoteNonConventionalSection section with commits that does not follow conventional commits.
+type ReleaseNoteNonConventionalSection struct {
+	Name  string
+	Items []GitCommitLog
+}
+
+// SectionType section type.
+func (ReleaseNoteNonConventionalSection) SectionType() string {
+	return ReleaseNotesSectionTypeNonConventional
+}
+
+// SectionName section name.
+func (s ReleaseNoteNonConventionalSection) SectionName() string {
+	return s.Name
+}
+
 // ReleaseNoteCommitsSection release note section.
 type ReleaseNoteCommitsSection struct {
 	Name  string
@@ -9,6 +9,14 @@ import (
 )
 
 func TestReleaseNoteProcessorImpl_Create(t *testing.T) {
+	exclamationCommit := commitlog("t1", map[string]string{}, "a")
+	exclamationCommit.Hash = "a1"
+	exclamationCommit.Message.Scope = "scope"
+	exclamationCommit.Message.IsBreakingChange = true
+	multipleBreakingCommit := commitlog("t2", map[string]string{"breaking-change": "first\n\nparagraph"}, "a")
+	multipleBreakingCommit.Hash = "b2"
+	multipleBreakingCommit.Message.MetadataValues = map[string][]string{"breaking-change": {"first\n\nparagraph", "second"}}
+
 	date := time.Now()
 
 	tests := []struct {
@@ -41,7 +49,27 @@ func TestReleaseNoteProcessorImpl_Create(t *testing.T) {
 			tag:     "v1.0.0",
 			date:    date,
 			commits: []GitCommitLog{commitlog("t1", map[string]string{}, "a"), commitlog("unmapped", map[string]string{"breaking-change": "breaks"}, "a")},
-			want:    releaseNote(semver.MustParse("1.0.0"), "v1.0.0", date, []ReleaseNoteSection{newReleaseNoteCommitsSection("Tag 1", []string{"t1"}, []GitCommitLog{commitlog("t1", map[string]string{}, "a")}), ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"breaks"}}}, map[string]struct{}{"a": {}}),
+			want:    releaseNote(semver.MustParse("1.0.0"), "v1.0.0", date, []ReleaseNoteSection{newReleaseNoteCommitsSection("Tag 1", []string{"t1"}, []GitCommitLog{commitlog("t1", map[string]string{}, "a")}), ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"breaks"}, Items: []BreakingChange{{Message: "breaks", Type: "unmapped", Commit: commitlog("unmapped", map[string]string{"breaking-change": "breaks"}, "a")}}}}, map[string]struct{}{"a": {}}),
+		},
+		{
+			name:    "breaking changes without footer and multiple footers",
+			version: semver.MustParse("1.0.0"),
+			tag:     "v1.0.0",
+			date:    date,
+			commits: []GitCommitLog{exclamationCommit, multipleBreakingCommit},
+			want: releaseNote(semver.MustParse("1.0.0"), "v1.0.0", date, []ReleaseNoteSection{newReleaseNoteCommitsSection("Tag 1", []string{"t1"}, []GitCommitLog{exclamationCommit}), newReleaseNoteCommitsSection("Tag 2", []string{"t2"}, []GitCommitLog{multipleBreakingCommit}), ReleaseNoteBreakingChangeSection{Name: "Breaking Changes", Messages: []string{"subject text", "first\n\nparagraph", "second"}, Items: []BreakingChange{
+				{Message: "subject text", Hash: "a1", Type: "t1", Scope: "scope", Commit: exclamationCommit},
+				{Message: "first\n\nparagraph", Hash: "b2", Type: "t2", Commit: multipleBreakingCommit},
+				{Message: "second", Hash: "b2", Type: "t2", Commit: multipleBreakingCommit},
+			}}}, map[string]struct{}{"a": {}}),
+		},
+		{
+			name:    "non-conventional commits",
+			version: semver.MustParse("1.0.0"),
+			tag:     "v1.0.0",
+			date:    date,
+			commits: []GitCommitLog{commitlog("t1", map[string]string{}, "a"), nonConventionalCommitlog("a")},
+			want:    releaseNote(semver.MustParse("1.0.0"), "v1.0.0", date, []ReleaseNoteSection{newReleaseNoteCommitsSection("Tag 1", []string{"t1"}, []GitCommitLog{commitlog("t1", map[string]string{}, "a")}), ReleaseNoteNonConventionalSection{Name: "Other Changes", Items: []GitCommitLog{nonConventionalCommitlog("a")}}}, map[string]struct{}{"a": {}}),
 		},
 		{
 			name:    "multiple authors",
@@ -54,7 +82,7 @@ func TestReleaseNoteProcessorImpl_Create(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Sections: []ReleaseNotesSectionConfig{{Name: "Tag 1", SectionType: "commits", CommitTypes: []string{"t1"}}, {Name: "Tag 2", SectionType: "commits", CommitTypes: []string{"t2"}}, {Name: "Breaking Changes", SectionType: "breaking-changes"}}})
+			p := NewReleaseNoteProcessor(ReleaseNotesConfig{Sections: []ReleaseNotesSectionConfig{{Name: "Tag 1", SectionType: "commits", CommitTypes: []string{"t1"}}, {Name: "Tag 2", SectionType: "commits", CommitTypes: []string{"t2"}}, {Name: "Breaking Changes", SectionType: "breaking-changes"}, {Name: "Other Changes", SectionType: "non-conventional"}}})
 			if got := p.Create(tt.version, tt.tag, tt.date, tt.commits); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ReleaseNoteProcessorImpl.Create() = %v, want %v", got, tt.want)
 			}
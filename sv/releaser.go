@@ -0,0 +1,628 @@
+package sv
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Bump types returned by VersionInfo.Bump.
+const (
+	BumpNone  = "none"
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+)
+
+const (
+	tagRefPrefix               = "tag: "
+	defaultBreakingChangesName = "Breaking Changes"
+)
+
+// Releaser high level release operations built on top of Git, SemVerCommitsProcessor and ReleaseNoteProcessor.
+type Releaser interface {
+	NextVersion(ctx context.Context) (VersionInfo, error)
+	TagCommits(ctx context.Context, tag string) ([]GitCommitLog, error)
+	ReleaseNote(ctx context.Context, opts ReleaseNoteOptions) (Release, error)
+	RangeNote(ctx context.Context, lr LogRange) (Release, error)
+	Changelog(ctx context.Context, opts ChangelogOptions) ([]Release, error)
+	ResolveVersionRange(ctx context.Context, lr LogRange) (LogRange, error)
+	UpgradeNote(ctx context.Context, opts UpgradeNoteOptions) (UpgradeNote, error)
+	Versions(ctx context.Context, opts VersionsOptions) ([]VersionTag, error)
+}
+
+// VersionInfo current and next version computed from commits since last tag.
+type VersionInfo struct {
+	Current    *semver.Version
+	CurrentTag string
+	Next       *semver.Version
+	NextTag    string
+	Updated    bool
+	Commits    []GitCommitLog
+}
+
+// CommitRange git revision range used to compute next version.
+func (i VersionInfo) CommitRange() string {
+	if i.CurrentTag == "" {
+		return "HEAD"
+	}
+	return i.CurrentTag + "..HEAD"
+}
+
+// Bump version component updated from current to next version: none, patch, minor or major.
+func (i VersionInfo) Bump() string {
+	switch {
+	case i.Current == nil || i.Next == nil || i.Current.Equal(i.Next):
+		return BumpNone
+	case i.Current.Major() != i.Next.Major():
+		return BumpMajor
+	case i.Current.Minor() != i.Next.Minor():
+		return BumpMinor
+	default:
+		return BumpPatch
+	}
+}
+
+// Release release note and commits used to create it.
+type Release struct {
+	ReleaseNote ReleaseNote
+	Commits     []GitCommitLog
+}
+
+// ReleaseNoteOptions options to create a release note.
+type ReleaseNoteOptions struct {
+	Tag string // Existing tag, if empty, release note is created for next version.
+}
+
+// ChangelogOptions options to create a changelog, releases are sorted from newest to oldest tag.
+type ChangelogOptions struct {
+	Size                int                 // Number of tags to include, ignored if All is true or Versions is defined.
+	All                 bool                // Include all tags.
+	AddNextVersion      bool                // Include next version, if updated, as first release.
+	SemanticVersionOnly bool                // Ignore tags that are not valid semantic versions.
+	Versions            *semver.Constraints // Include only tags which version, parsed using tag pattern, matches constraint.
+}
+
+// UpgradeNoteOptions options to create an upgrade note.
+type UpgradeNoteOptions struct {
+	From string // Current version, not included, if empty, all versions until To are included.
+	To   string // Target version, included, if empty, last version is used.
+}
+
+// UpgradeNote release notes of all versions after From until To merged in a single release note.
+type UpgradeNote struct {
+	From            *semver.Version
+	To              *semver.Version
+	ReleaseNote     ReleaseNote // Release note with commits of all versions, breaking changes section is removed.
+	BreakingChanges []UpgradeNoteBreakingChange
+	Releases        []Release // Releases included, from newest to oldest.
+
+	BreakingChangesName string // Breaking changes section name from config or default.
+}
+
+// UpgradeNoteBreakingChange breaking change and the version that introduced it.
+type UpgradeNoteBreakingChange struct {
+	BreakingChange
+	Version *semver.Version // Version parsed from tag using tag pattern, nil if tag does not match pattern.
+	Tag     string
+}
+
+// VersionsOptions options to list versions.
+type VersionsOptions struct {
+	Constraint     *semver.Constraints // Only versions matching constraint, all versions if nil.
+	LatestPerMajor bool                // Only the highest version of each major.
+	LatestPerMinor bool                // Only the highest version of each major.minor.
+}
+
+// VersionTag tag recognized as a version using tag pattern.
+type VersionTag struct {
+	Version *semver.Version
+	Tag     string
+	Date    time.Time
+	Hash    string
+}
+
+// ReleaserImpl Releaser implementation.
+type ReleaserImpl struct {
+	git                  GitContext
+	semverProcessor      SemVerCommitsProcessor
+	releaseNoteProcessor ReleaseNoteProcessor
+	tagCfg               TagConfig
+	cache                *Cache
+}
+
+// NewReleaser Releaser constructor, git commands are canceled with context if git implements GitContext.
+func NewReleaser(git Git, semverProcessor SemVerCommitsProcessor, releaseNoteProcessor ReleaseNoteProcessor, tcfg TagConfig) *ReleaserImpl {
+	return &ReleaserImpl{
+		git:                  withContext(git),
+		semverProcessor:      semverProcessor,
+		releaseNoteProcessor: releaseNoteProcessor,
+		tagCfg:               tcfg,
+	}
+}
+
+// WithCache return a copy that reuses commits of tags from cache on changelog and stores new ones on it.
+func (r ReleaserImpl) WithCache(cache *Cache) *ReleaserImpl {
+	r.cache = cache
+	return &r
+}
+
+// NextVersion compute next version using commits since last tag, last tag must be a valid version.
+func (r ReleaserImpl) NextVersion(ctx context.Context) (VersionInfo, error) {
+	lastTag, err := r.git.LastTagContext(ctx)
+	if err != nil {
+		return VersionInfo{}, fmt.Errorf("error getting last tag, message: %w", err)
+	}
+	if _, err := ToVersion(lastTag); err != nil {
+		return VersionInfo{}, fmt.Errorf("error parsing version: %s from git tag, message: %v", lastTag, err)
+	}
+	return r.nextVersion(ctx, lastTag)
+}
+
+func (r ReleaserImpl) nextVersion(ctx context.Context, lastTag string) (VersionInfo, error) {
+	commits, err := r.git.LogContext(ctx, NewLogRange(TagRange, lastTag, ""))
+	if err != nil {
+		return VersionInfo{}, fmt.Errorf("error getting git log, message: %v", err)
+	}
+
+	currentVer, _ := ToVersion(lastTag)
+	nextVer, updated := r.semverProcessor.NextVersion(currentVer, commits)
+
+	info := VersionInfo{Current: currentVer, CurrentTag: lastTag, Next: nextVer, Updated: updated, Commits: commits}
+	if nextVer != nil {
+		info.NextTag = r.tagCfg.TagName(*nextVer)
+	}
+	return info, nil
+}
+
+// TagCommits list commits between previous tag and tag.
+func (r ReleaserImpl) TagCommits(ctx context.Context, tag string) ([]GitCommitLog, error) {
+	previousTag, _, err := r.tagBounds(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	return r.git.LogContext(ctx, NewLogRange(TagRange, previousTag, tag))
+}
+
+// ReleaseNote create release note for an existing tag or, if tag is not defined, for next version.
+func (r ReleaserImpl) ReleaseNote(ctx context.Context, opts ReleaseNoteOptions) (Release, error) {
+	if opts.Tag == "" {
+		// TODO: should generate release notes if version was not updated?
+		lastTag, err := r.git.LastTagContext(ctx)
+		if err != nil {
+			return Release{}, fmt.Errorf("error getting last tag, message: %w", err)
+		}
+		info, err := r.nextVersion(ctx, lastTag)
+		if err != nil {
+			return Release{}, err
+		}
+		return r.release(info.Next, "", time.Now(), info.Commits), nil
+	}
+
+	previousTag, currentTag, err := r.tagBounds(ctx, opts.Tag)
+	if err != nil {
+		return Release{}, fmt.Errorf("error listing tags, message: %v", err)
+	}
+
+	commits, err := r.git.LogContext(ctx, NewLogRange(TagRange, previousTag, opts.Tag))
+	if err != nil {
+		return Release{}, fmt.Errorf("error getting git log from tag: %s, message: %v", opts.Tag, err)
+	}
+
+	tagVersion, _ := ToVersion(opts.Tag)
+	return r.release(tagVersion, opts.Tag, currentTag.Date, commits), nil
+}
+
+// RangeNote create release note, without version, for commits in range, date is the newest commit date.
+func (r ReleaserImpl) RangeNote(ctx context.Context, lr LogRange) (Release, error) {
+	lr, err := r.ResolveVersionRange(ctx, lr)
+	if err != nil {
+		return Release{}, err
+	}
+
+	commits, err := r.git.LogContext(ctx, lr)
+	if err != nil {
+		return Release{}, fmt.Errorf("error getting git log from range: %s, message: %v", lr.Type(), err)
+	}
+
+	var date time.Time
+	if len(commits) > 0 {
+		date, _ = time.Parse("2006-01-02", commits[0].Date)
+	}
+	return r.release(nil, "", date, commits), nil
+}
+
+// Changelog create release notes for tags, from newest to oldest.
+func (r ReleaserImpl) Changelog(ctx context.Context, opts ChangelogOptions) ([]Release, error) {
+	tags, err := r.git.TagsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Date.After(tags[j].Date)
+	})
+
+	var releases []Release
+	if opts.AddNextVersion {
+		lastTag, err := r.git.LastTagContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting last tag, message: %w", err)
+		}
+		info, err := r.nextVersion(ctx, lastTag)
+		if err != nil {
+			return nil, err
+		}
+		if info.Updated {
+			releases = append(releases, r.release(info.Next, "", time.Now(), info.Commits))
+		}
+	}
+
+	start, end := 0, len(tags) // tags[end], if exists, is only used as range boundary
+	switch {
+	case opts.Versions != nil:
+		start, end = r.versionTagsSpan(tags, opts.Versions)
+	case !opts.All && opts.Size < len(tags):
+		end = opts.Size
+	}
+	if start >= end {
+		return releases, nil
+	}
+
+	previousTag := ""
+	if end < len(tags) {
+		previousTag = tags[end].Name
+	}
+	tags = tags[start:end]
+
+	commitsByTag, err := r.changelogCommits(ctx, tags, previousTag)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if opts.SemanticVersionOnly && !IsValidVersion(tag.Name) {
+			continue
+		}
+		if opts.Versions != nil && !r.matchVersion(tag.Name, opts.Versions) {
+			continue
+		}
+
+		currentVer, _ := ToVersion(tag.Name)
+		releases = append(releases, r.release(currentVer, tag.Name, tag.Date, commitsByTag[tag.Name]))
+	}
+	return releases, nil
+}
+
+// ResolveVersionRange convert a VersionRange to a TagRange with commits of all versions on range, other range types are returned as is.
+// Start and end are versions, both included, or start is a semver constraint, eg.: ">=1.2 <2", tags are matched using tag pattern.
+func (r ReleaserImpl) ResolveVersionRange(ctx context.Context, lr LogRange) (LogRange, error) {
+	if lr.Type() != VersionRange {
+		return lr, nil
+	}
+
+	constraint, err := NewVersionConstraint(lr.Start(), lr.End())
+	if err != nil {
+		return LogRange{}, err
+	}
+
+	tags, err := r.git.TagsContext(ctx)
+	if err != nil {
+		return LogRange{}, err
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Date.After(tags[j].Date)
+	})
+
+	start, end := r.versionTagsSpan(tags, constraint)
+	if start >= end {
+		return LogRange{}, fmt.Errorf("no tag found for version range: %s", constraint)
+	}
+	for _, tag := range tags[start:end] {
+		if r.outOfRange(tag.Name, constraint) {
+			return LogRange{}, fmt.Errorf("tags of version range %s are not contiguous, version %s was created between them", constraint, tag.Name)
+		}
+	}
+
+	previousTag := ""
+	if end < len(tags) {
+		previousTag = tags[end].Name
+	}
+	return NewLogRange(TagRange, previousTag, tags[start].Name), nil
+}
+
+// UpgradeNote merge release notes of all versions after From until To, breaking changes are listed with the version that introduced them.
+func (r ReleaserImpl) UpgradeNote(ctx context.Context, opts UpgradeNoteOptions) (UpgradeNote, error) {
+	var constraints []string
+	if opts.From != "" {
+		if !IsValidVersion(opts.From) {
+			return UpgradeNote{}, fmt.Errorf("invalid from version: %s", opts.From)
+		}
+		constraints = append(constraints, "> "+opts.From)
+	}
+	if opts.To != "" {
+		if !IsValidVersion(opts.To) {
+			return UpgradeNote{}, fmt.Errorf("invalid to version: %s", opts.To)
+		}
+		constraints = append(constraints, "<= "+opts.To)
+	}
+	constraint, err := semver.NewConstraint(str(strings.Join(constraints, ", "), "*"))
+	if err != nil {
+		return UpgradeNote{}, err
+	}
+
+	tags, err := r.git.TagsContext(ctx)
+	if err != nil {
+		return UpgradeNote{}, err
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Date.After(tags[j].Date)
+	})
+
+	start, end := r.versionTagsSpan(tags, constraint)
+	if start >= end {
+		return UpgradeNote{}, fmt.Errorf("no version found for range: %s", constraint)
+	}
+	previousTag := ""
+	if end < len(tags) {
+		previousTag = tags[end].Name
+	}
+	tags = tags[start:end]
+
+	commitsByTag, err := r.changelogCommits(ctx, tags, previousTag)
+	if err != nil {
+		return UpgradeNote{}, err
+	}
+
+	var from *semver.Version
+	if opts.From != "" {
+		from, _ = semver.NewVersion(opts.From)
+	}
+	to, _ := r.tagCfg.TagVersion(tags[0].Name)
+	note := UpgradeNote{From: from, To: to, BreakingChangesName: defaultBreakingChangesName}
+
+	var allCommits []GitCommitLog
+	for _, tag := range tags {
+		if r.outOfRange(tag.Name, constraint) {
+			continue
+		}
+		commits := commitsByTag[tag.Name]
+		version, _ := r.tagCfg.TagVersion(tag.Name)
+		note.Releases = append(note.Releases, r.release(version, tag.Name, tag.Date, commits))
+		allCommits = append(allCommits, commits...)
+
+		for _, commit := range commits {
+			for _, bc := range commitBreakingChanges(commit) {
+				note.BreakingChanges = append(note.BreakingChanges, UpgradeNoteBreakingChange{BreakingChange: bc, Version: version, Tag: tag.Name})
+			}
+		}
+	}
+
+	note.ReleaseNote = r.releaseNoteProcessor.Create(to, tags[0].Name, tags[0].Date, allCommits)
+	sections := note.ReleaseNote.Sections[:0:0]
+	for _, section := range note.ReleaseNote.Sections {
+		if bc, ok := section.(ReleaseNoteBreakingChangeSection); ok {
+			note.BreakingChangesName = str(bc.Name, note.BreakingChangesName)
+			continue
+		}
+		if cs, ok := section.(ReleaseNoteCommitsSection); ok {
+			cs.Items = dedupeCommits(cs.Items)
+			section = cs
+		}
+		sections = append(sections, section)
+	}
+	note.ReleaseNote.Sections = sections
+	return note, nil
+}
+
+// dedupeCommits remove commits with same type, scope and description, e.g. fixes cherry-picked to multiple versions, keeping the newest.
+func dedupeCommits(commits []GitCommitLog) []GitCommitLog {
+	type key struct{ ctype, scope, description string }
+	seen := make(map[key]bool)
+	result := commits[:0:0]
+	for _, commit := range commits {
+		k := key{commit.Message.Type, commit.Message.Scope, commit.Message.Description}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, commit)
+	}
+	return result
+}
+
+// Versions list tags recognized as versions sorted by semver, from lowest to highest.
+func (r ReleaserImpl) Versions(ctx context.Context, opts VersionsOptions) ([]VersionTag, error) {
+	tags, err := r.git.TagsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []VersionTag
+	for _, tag := range tags {
+		version, ok := r.tagCfg.TagVersion(tag.Name)
+		if !ok || (opts.Constraint != nil && !opts.Constraint.Check(version)) {
+			continue
+		}
+		versions = append(versions, VersionTag{Version: version, Tag: tag.Name, Date: tag.Date, Hash: tag.Hash})
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Version.LessThan(versions[j].Version)
+	})
+
+	if opts.LatestPerMinor {
+		versions = latestVersions(versions, func(v *semver.Version) string { return fmt.Sprintf("%d.%d", v.Major(), v.Minor()) })
+	}
+	if opts.LatestPerMajor {
+		versions = latestVersions(versions, func(v *semver.Version) string { return fmt.Sprint(v.Major()) })
+	}
+	return versions, nil
+}
+
+// latestVersions keep only the last version of each group, versions must be sorted.
+func latestVersions(versions []VersionTag, group func(*semver.Version) string) []VersionTag {
+	var result []VersionTag
+	for i, v := range versions {
+		if i == len(versions)-1 || group(v.Version) != group(versions[i+1].Version) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// versionTagsSpan find first and last, exclusive, positions of tags that match constraint, tags must be sorted from newest to oldest.
+func (r ReleaserImpl) versionTagsSpan(tags []GitTag, constraint *semver.Constraints) (int, int) {
+	start, end := len(tags), 0
+	for i, tag := range tags {
+		if r.matchVersion(tag.Name, constraint) {
+			if i < start {
+				start = i
+			}
+			end = i + 1
+		}
+	}
+	return start, end
+}
+
+func (r ReleaserImpl) matchVersion(tag string, constraint *semver.Constraints) bool {
+	version, ok := r.tagCfg.TagVersion(tag)
+	return ok && constraint.Check(version)
+}
+
+// outOfRange check if tag is a version that does not match constraint, tags that are not versions are never out of range.
+func (r ReleaserImpl) outOfRange(tag string, constraint *semver.Constraints) bool {
+	version, ok := r.tagCfg.TagVersion(tag)
+	return ok && !constraint.Check(version)
+}
+
+// changelogCommits list commits of each tag using a single git log, commits are grouped by tags found on their refs.
+// Oldest tags found on cache are not included on git log.
+// If tags order does not match history, eg.: a tag created on an old commit, or history has merges, it falls back to one git log per tag.
+func (r ReleaserImpl) changelogCommits(ctx context.Context, tags []GitTag, previousTag string) (map[string][]GitCommitLog, error) {
+	commitsByTag := make(map[string][]GitCommitLog, len(tags))
+	for len(tags) > 0 {
+		commits, cached := r.cache.TagCommits(tags[len(tags)-1], previousTag)
+		if !cached {
+			break
+		}
+		commitsByTag[tags[len(tags)-1].Name] = commits
+		previousTag = tags[len(tags)-1].Name
+		tags = tags[:len(tags)-1]
+	}
+	if len(tags) == 0 {
+		return commitsByTag, nil
+	}
+
+	logged, err := r.logCommitsByTag(ctx, tags, previousTag)
+	if err != nil {
+		return nil, err
+	}
+	for i, tag := range tags {
+		previous := previousTag
+		if i+1 < len(tags) {
+			previous = tags[i+1].Name
+		}
+		r.cache.SetTagCommits(tag, previous, logged[tag.Name])
+		commitsByTag[tag.Name] = logged[tag.Name]
+	}
+	return commitsByTag, nil
+}
+
+func (r ReleaserImpl) logCommitsByTag(ctx context.Context, tags []GitTag, previousTag string) (map[string][]GitCommitLog, error) {
+	commits, err := r.git.LogContext(ctx, NewLogRange(TagRange, previousTag, tags[0].Name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting git log from tag: %s, message: %v", tags[0].Name, err)
+	}
+	if commitsByTag, ok := groupCommitsByTag(tags, commits); ok {
+		return commitsByTag, nil
+	}
+
+	commitsByTag := make(map[string][]GitCommitLog, len(tags))
+	for i, tag := range tags {
+		previous := previousTag
+		if i+1 < len(tags) {
+			previous = tags[i+1].Name
+		}
+
+		commits, err := r.git.LogContext(ctx, NewLogRange(TagRange, previous, tag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("error getting git log from tag: %s, message: %v", tag.Name, err)
+		}
+		commitsByTag[tag.Name] = commits
+	}
+	return commitsByTag, nil
+}
+
+// groupCommitsByTag assign each commit, from newest to oldest, to the tag found on its refs or on the refs of a newer commit, tags must be sorted from newest to oldest.
+// When a commit has more than one tag, commits are assigned to the oldest one, same as using each pair of tags as range.
+// Return false if tags are not found on commits in the same order or history is not linear, eg.: commits from merged branches
+// created before a tag are listed after it, so grouping would differ from using each pair of tags as range.
+func groupCommitsByTag(tags []GitTag, commits []GitCommitLog) (map[string][]GitCommitLog, bool) {
+	if !linearHistory(commits) {
+		return nil, false
+	}
+
+	position := make(map[string]int, len(tags))
+	for i, tag := range tags {
+		position[tag.Name] = i
+	}
+
+	result := make(map[string][]GitCommitLog, len(tags))
+	current := -1
+	for _, commit := range commits {
+		var found []int
+		for _, ref := range commit.Refs {
+			if i, exists := position[strings.TrimPrefix(ref, tagRefPrefix)]; exists && strings.HasPrefix(ref, tagRefPrefix) {
+				found = append(found, i)
+			}
+		}
+		sort.Ints(found)
+		for _, i := range found {
+			if i != current+1 {
+				return nil, false
+			}
+			current = i
+		}
+		if current < 0 {
+			return nil, false
+		}
+		result[tags[current].Name] = append(result[tags[current].Name], commit)
+	}
+	return result, current == len(tags)-1
+}
+
+// linearHistory check if each commit has a single parent and it is the next commit on list.
+func linearHistory(commits []GitCommitLog) bool {
+	for i, commit := range commits {
+		if len(commit.Parents) > 1 || (i+1 < len(commits) && (len(commit.Parents) == 0 || commit.Parents[0] != commits[i+1].FullHash)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r ReleaserImpl) release(version *semver.Version, tag string, date time.Time, commits []GitCommitLog) Release {
+	return Release{ReleaseNote: r.releaseNoteProcessor.Create(version, tag, date, commits), Commits: commits}
+}
+
+// tagBounds find tag and its previous tag, previous tag is empty if tag is the first one.
+func (r ReleaserImpl) tagBounds(ctx context.Context, tag string) (string, GitTag, error) {
+	tags, err := r.git.TagsContext(ctx)
+	if err != nil {
+		return "", GitTag{}, err
+	}
+
+	for i := range tags {
+		if tags[i].Name != tag {
+			continue
+		}
+		if i > 0 {
+			return tags[i-1].Name, tags[i], nil
+		}
+		return "", tags[i], nil
+	}
+	return "", GitTag{}, fmt.Errorf("tag: %s not found, check tag filter", tag)
+}
@@ -0,0 +1,547 @@
+package sv_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bvieira/sv4git/v2/sv"
+	"github.com/bvieira/sv4git/v2/svtest"
+)
+
+var releaserMessageCfg = sv.CommitMessageConfig{Types: []string{"feat", "fix", "chore"}}
+
+func newReleaser(git sv.Git, filter string) *sv.ReleaserImpl {
+	pattern := "v%d.%d.%d"
+	tcfg := sv.TagConfig{Pattern: &pattern, Filter: &filter}
+	semverProcessor := sv.NewSemVerCommitsProcessor(sv.VersioningConfig{UpdateMinor: []string{"feat"}, UpdatePatch: []string{"fix"}, IgnoreUnknown: true}, releaserMessageCfg)
+	rnProcessor := sv.NewReleaseNoteProcessor(sv.ReleaseNotesConfig{Sections: []sv.ReleaseNotesSectionConfig{
+		{Name: "Features", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}},
+		{Name: "Bug Fixes", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix"}},
+	}})
+	return sv.NewReleaser(git, semverProcessor, rnProcessor, tcfg)
+}
+
+func releaserGit(filter string) *svtest.Git {
+	pattern := "v%d.%d.%d"
+	g := svtest.NewGit(sv.NewMessageProcessor(releaserMessageCfg, sv.BranchesConfig{}), sv.TagConfig{Pattern: &pattern, Filter: &filter}, sv.LogConfig{})
+	g.AddCommit("feat: first feature")
+	g.AddTag("v1.0.0")
+	g.AddCommit("fix: first fix")
+	g.AddTag("v1.0.1")
+	g.AddCommit("chore: update dependencies")
+	g.AddTag("latest")
+	g.AddCommit("feat: second feature")
+	return g
+}
+
+func descriptions(commits []sv.GitCommitLog) []string {
+	var result []string
+	for _, commit := range commits {
+		result = append(result, commit.Message.Description)
+	}
+	return result
+}
+
+func releaseTags(releases []sv.Release) []string {
+	var result []string
+	for _, release := range releases {
+		result = append(result, release.ReleaseNote.Tag)
+	}
+	return result
+}
+
+func TestReleaserImpl_NextVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    sv.VersionInfo
+		wantErr bool
+	}{
+		{"last tag is not a version", "", sv.VersionInfo{}, true},
+		{"filter versions", "v*", sv.VersionInfo{Current: semver.MustParse("1.0.1"), CurrentTag: "v1.0.1", Next: semver.MustParse("1.1.0"), NextTag: "v1.1.0", Updated: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReleaser(releaserGit(tt.filter), tt.filter).NextVersion(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReleaserImpl.NextVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Current.Equal(tt.want.Current) || got.CurrentTag != tt.want.CurrentTag || !got.Next.Equal(tt.want.Next) || got.NextTag != tt.want.NextTag || got.Updated != tt.want.Updated {
+				t.Errorf("ReleaserImpl.NextVersion() = %+v, want %+v", got, tt.want)
+			}
+			if want := []string{"second feature", "update dependencies"}; !reflect.DeepEqual(descriptions(got.Commits), want) {
+				t.Errorf("ReleaserImpl.NextVersion() commits = %v, want %v", descriptions(got.Commits), want)
+			}
+		})
+	}
+}
+
+func TestReleaserImpl_ReleaseNote(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        sv.ReleaseNoteOptions
+		wantVersion *semver.Version
+		wantCommits []string
+		wantErr     bool
+	}{
+		{"first tag", sv.ReleaseNoteOptions{Tag: "v1.0.0"}, semver.MustParse("1.0.0"), []string{"first feature"}, false},
+		{"tag", sv.ReleaseNoteOptions{Tag: "v1.0.1"}, semver.MustParse("1.0.1"), []string{"first fix"}, false},
+		{"next version", sv.ReleaseNoteOptions{}, semver.MustParse("1.1.0"), []string{"second feature", "update dependencies"}, false},
+		{"filtered tag", sv.ReleaseNoteOptions{Tag: "latest"}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReleaser(releaserGit("v*"), "v*").ReleaseNote(context.Background(), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReleaserImpl.ReleaseNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.ReleaseNote.Version.Equal(tt.wantVersion) || got.ReleaseNote.Tag != tt.opts.Tag {
+				t.Errorf("ReleaserImpl.ReleaseNote() version = %v, tag = %v, want %v, %v", got.ReleaseNote.Version, got.ReleaseNote.Tag, tt.wantVersion, tt.opts.Tag)
+			}
+			if !reflect.DeepEqual(descriptions(got.Commits), tt.wantCommits) {
+				t.Errorf("ReleaserImpl.ReleaseNote() commits = %v, want %v", descriptions(got.Commits), tt.wantCommits)
+			}
+		})
+	}
+}
+
+func TestReleaserImpl_TagCommits(t *testing.T) {
+	got, err := newReleaser(releaserGit(""), "").TagCommits(context.Background(), "latest")
+	if err != nil {
+		t.Fatalf("ReleaserImpl.TagCommits() error = %v", err)
+	}
+	if want := []string{"update dependencies"}; !reflect.DeepEqual(descriptions(got), want) {
+		t.Errorf("ReleaserImpl.TagCommits() = %v, want %v", descriptions(got), want)
+	}
+}
+
+func TestReleaserImpl_RangeNote(t *testing.T) {
+	got, err := newReleaser(releaserGit(""), "").RangeNote(context.Background(), sv.NewLogRange(sv.TagRange, "v1.0.0", "v1.0.1"))
+	if err != nil {
+		t.Fatalf("ReleaserImpl.RangeNote() error = %v", err)
+	}
+	if got.ReleaseNote.Version != nil || !got.ReleaseNote.Date.Equal(svtest.DefaultDate) {
+		t.Errorf("ReleaserImpl.RangeNote() version = %v, date = %v, want nil, %v", got.ReleaseNote.Version, got.ReleaseNote.Date, svtest.DefaultDate)
+	}
+	if want := []string{"first fix"}; !reflect.DeepEqual(descriptions(got.Commits), want) {
+		t.Errorf("ReleaserImpl.RangeNote() commits = %v, want %v", descriptions(got.Commits), want)
+	}
+}
+
+func TestReleaserImpl_Changelog(t *testing.T) {
+	tests := []struct {
+		name string
+		opts sv.ChangelogOptions
+		want []string
+	}{
+		{"size", sv.ChangelogOptions{Size: 2}, []string{"latest", "v1.0.1"}},
+		{"all", sv.ChangelogOptions{All: true}, []string{"latest", "v1.0.1", "v1.0.0"}},
+		{"semantic version only", sv.ChangelogOptions{Size: 2, SemanticVersionOnly: true}, []string{"v1.0.1"}},
+		{"add next version", sv.ChangelogOptions{All: true, AddNextVersion: true}, []string{"", "latest", "v1.0.1", "v1.0.0"}},
+		{"versions", sv.ChangelogOptions{Size: 1, Versions: versionConstraint(t, ">=1.0.1")}, []string{"v1.0.1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReleaser(releaserGit(""), "").Changelog(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("ReleaserImpl.Changelog() error = %v", err)
+			}
+			if !reflect.DeepEqual(releaseTags(got), tt.want) {
+				t.Errorf("ReleaserImpl.Changelog() tags = %v, want %v", releaseTags(got), tt.want)
+			}
+		})
+	}
+}
+
+func versionConstraint(t *testing.T, start string) *semver.Constraints {
+	c, err := sv.NewVersionConstraint(start, "")
+	if err != nil {
+		t.Fatalf("NewVersionConstraint() error = %v", err)
+	}
+	return c
+}
+
+func TestReleaserImpl_ResolveVersionRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		lr      sv.LogRange
+		want    sv.LogRange
+		wantErr bool
+	}{
+		{"not a version range", sv.NewLogRange(sv.HashRange, "a", "b"), sv.NewLogRange(sv.HashRange, "a", "b"), false},
+		{"single version", sv.NewLogRange(sv.VersionRange, "1.0.1", "1.0.1"), sv.NewLogRange(sv.TagRange, "v1.0.0", "v1.0.1"), false},
+		{"start and end", sv.NewLogRange(sv.VersionRange, "1.0.0", "1.0.1"), sv.NewLogRange(sv.TagRange, "", "v1.0.1"), false},
+		{"constraint", sv.NewLogRange(sv.VersionRange, "<1.0.1", ""), sv.NewLogRange(sv.TagRange, "", "v1.0.0"), false},
+		{"no version found", sv.NewLogRange(sv.VersionRange, ">=2", ""), sv.LogRange{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReleaser(releaserGit(""), "").ResolveVersionRange(context.Background(), tt.lr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReleaserImpl.ResolveVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ReleaserImpl.ResolveVersionRange() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaserImpl_ResolveVersionRangeInterleavedTags(t *testing.T) {
+	g := releaserGit("")
+	g.AddTag("v2.0.0")
+	g.AddCommit("fix: hotfix on 1.x")
+	g.AddTag("v1.0.2")
+	g.AddCommit("feat: feature on 2.x")
+	g.AddTag("v2.1.0")
+
+	tests := []struct {
+		name    string
+		lr      sv.LogRange
+		want    sv.LogRange
+		wantErr bool
+	}{
+		{"hotfix tag between versions", sv.NewLogRange(sv.VersionRange, ">=2 <3", ""), sv.LogRange{}, true},
+		{"versions between hotfix tags", sv.NewLogRange(sv.VersionRange, "1.0.1", "1.0.2"), sv.LogRange{}, true},
+		{"contiguous versions", sv.NewLogRange(sv.VersionRange, "2.1.0", "2.1.0"), sv.NewLogRange(sv.TagRange, "v1.0.2", "v2.1.0"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReleaser(g, "").ResolveVersionRange(context.Background(), tt.lr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReleaserImpl.ResolveVersionRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ReleaserImpl.ResolveVersionRange() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	note, err := newReleaser(g, "").UpgradeNote(context.Background(), sv.UpgradeNoteOptions{From: "1.0.2"})
+	if want := []string{"v2.1.0", "v2.0.0"}; err != nil || !reflect.DeepEqual(releaseTags(note.Releases), want) {
+		t.Errorf("ReleaserImpl.UpgradeNote() releases = %v, %v, want %v", releaseTags(note.Releases), err, want)
+	}
+}
+
+type countingGit struct {
+	*svtest.Git
+	logCalls int
+}
+
+func (g *countingGit) LogContext(ctx context.Context, lr sv.LogRange) ([]sv.GitCommitLog, error) {
+	g.logCalls++
+	return g.Git.LogContext(ctx, lr)
+}
+
+func TestReleaserImpl_ChangelogSingleLog(t *testing.T) {
+	g := &countingGit{Git: releaserGit("")}
+	g.AddTag("v1.1.0")
+	g.AddTag("v1.1.0-same-commit")
+
+	got, err := newReleaser(g, "").Changelog(context.Background(), sv.ChangelogOptions{All: true})
+	if err != nil {
+		t.Fatalf("ReleaserImpl.Changelog() error = %v", err)
+	}
+	if g.logCalls != 1 {
+		t.Errorf("ReleaserImpl.Changelog() git log calls = %d, want 1", g.logCalls)
+	}
+
+	want := map[string][]string{
+		"v1.1.0-same-commit": nil,
+		"v1.1.0":             {"second feature"},
+		"latest":             {"update dependencies"},
+		"v1.0.1":             {"first fix"},
+		"v1.0.0":             {"first feature"},
+	}
+	if wantTags := []string{"v1.1.0-same-commit", "v1.1.0", "latest", "v1.0.1", "v1.0.0"}; !reflect.DeepEqual(releaseTags(got), wantTags) {
+		t.Fatalf("ReleaserImpl.Changelog() tags = %v, want %v", releaseTags(got), wantTags)
+	}
+	for _, release := range got {
+		if !reflect.DeepEqual(descriptions(release.Commits), want[release.ReleaseNote.Tag]) {
+			t.Errorf("ReleaserImpl.Changelog() %s commits = %v, want %v", release.ReleaseNote.Tag, descriptions(release.Commits), want[release.ReleaseNote.Tag])
+		}
+	}
+}
+
+func TestVersionInfo_Bump(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *semver.Version
+		next    *semver.Version
+		want    string
+	}{
+		{"none", semver.MustParse("1.0.0"), semver.MustParse("1.0.0"), sv.BumpNone},
+		{"patch", semver.MustParse("1.0.0"), semver.MustParse("1.0.1"), sv.BumpPatch},
+		{"minor", semver.MustParse("1.0.1"), semver.MustParse("1.1.0"), sv.BumpMinor},
+		{"major", semver.MustParse("1.1.1"), semver.MustParse("2.0.0"), sv.BumpMajor},
+		{"prerelease", semver.MustParse("1.1.0-rc.1"), semver.MustParse("1.1.0"), sv.BumpPatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (sv.VersionInfo{Current: tt.current, Next: tt.next}).Bump(); got != tt.want {
+				t.Errorf("VersionInfo.Bump() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaserImpl_ChangelogTagOnOldCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found on PATH")
+	}
+
+	r := svtest.NewRepo(t)
+	r.Commit("feat: first feature")
+	r.Tag("v1.0.0")
+	r.Commit("fix: first fix")
+	r.Commit("feat: second feature")
+	r.Tag("v1.1.0")
+	r.Run("tag", "-a", "v1.0.1", "-m", "v1.0.1", "HEAD~1")
+
+	pattern := "v%d.%d.%d"
+	filter := ""
+	g := r.Git(sv.NewMessageProcessor(releaserMessageCfg, sv.BranchesConfig{}), sv.TagConfig{Pattern: &pattern, Filter: &filter}, sv.LogConfig{})
+	got, err := newReleaser(g, "").Changelog(context.Background(), sv.ChangelogOptions{All: true})
+	if err != nil {
+		t.Fatalf("ReleaserImpl.Changelog() error = %v", err)
+	}
+
+	want := map[string][]string{"v1.0.1": nil, "v1.1.0": {"second feature", "first fix"}, "v1.0.0": {"first feature"}}
+	if wantTags := []string{"v1.0.1", "v1.1.0", "v1.0.0"}; !reflect.DeepEqual(releaseTags(got), wantTags) {
+		t.Fatalf("ReleaserImpl.Changelog() tags = %v, want %v", releaseTags(got), wantTags)
+	}
+	for _, release := range got {
+		if !reflect.DeepEqual(descriptions(release.Commits), want[release.ReleaseNote.Tag]) {
+			t.Errorf("ReleaserImpl.Changelog() %s commits = %v, want %v", release.ReleaseNote.Tag, descriptions(release.Commits), want[release.ReleaseNote.Tag])
+		}
+	}
+}
+
+func TestReleaserImpl_ChangelogCache(t *testing.T) {
+	cache, err := sv.NewCache(t.TempDir(), "config")
+	if err != nil {
+		t.Fatalf("NewCache() error = %v", err)
+	}
+
+	g := &countingGit{Git: releaserGit("")}
+	first, err := newReleaser(g, "").WithCache(cache).Changelog(context.Background(), sv.ChangelogOptions{All: true})
+	if err != nil {
+		t.Fatalf("ReleaserImpl.Changelog() error = %v", err)
+	}
+
+	g.logCalls = 0
+	g.AddTag("v1.1.0")
+	got, err := newReleaser(g, "").WithCache(cache).Changelog(context.Background(), sv.ChangelogOptions{All: true})
+	if err != nil {
+		t.Fatalf("ReleaserImpl.Changelog() error = %v", err)
+	}
+	if g.logCalls != 1 {
+		t.Errorf("ReleaserImpl.Changelog() git log calls = %d, want 1", g.logCalls)
+	}
+	if !reflect.DeepEqual(got[1:], first) {
+		t.Errorf("ReleaserImpl.Changelog() cached releases = %+v, want %+v", got[1:], first)
+	}
+	if want := []string{"second feature"}; !reflect.DeepEqual(descriptions(got[0].Commits), want) {
+		t.Errorf("ReleaserImpl.Changelog() new tag commits = %v, want %v", descriptions(got[0].Commits), want)
+	}
+}
+
+func upgradeGit() *svtest.Git {
+	pattern := "v%d.%d.%d"
+	filter := ""
+	g := svtest.NewGit(sv.NewMessageProcessor(releaserMessageCfg, sv.BranchesConfig{}), sv.TagConfig{Pattern: &pattern, Filter: &filter}, sv.LogConfig{})
+	g.AddCommit("feat: first feature")
+	g.AddTag("v1.0.0")
+	g.AddCommit("feat!: remove option\n\nBREAKING CHANGE: option removed")
+	g.AddCommit("fix: first fix")
+	g.AddTag("v2.0.0")
+	g.AddCommit("fix: second fix")
+	g.AddTag("v2.0.1")
+	g.AddCommit("feat: second feature\n\nBREAKING CHANGE: config renamed")
+	g.AddCommit("fix: first fix")
+	g.AddTag("v3.0.0")
+	return g
+}
+
+func TestReleaserImpl_UpgradeNote(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         sv.UpgradeNoteOptions
+		wantReleases []string
+		wantBreaking []string
+		wantSections map[string][]string
+		wantErr      bool
+	}{
+		{"from version", sv.UpgradeNoteOptions{From: "1.0.0", To: "2.0.1"}, []string{"v2.0.1", "v2.0.0"}, []string{"v2.0.0: option removed"}, map[string][]string{"Features": {"remove option"}, "Bug Fixes": {"second fix", "first fix"}}, false},
+		{"until last version", sv.UpgradeNoteOptions{From: "2.0.0"}, []string{"v3.0.0", "v2.0.1"}, []string{"v3.0.0: config renamed"}, map[string][]string{"Features": {"second feature"}, "Bug Fixes": {"first fix", "second fix"}}, false},
+		{"all versions", sv.UpgradeNoteOptions{}, []string{"v3.0.0", "v2.0.1", "v2.0.0", "v1.0.0"}, []string{"v3.0.0: config renamed", "v2.0.0: option removed"}, map[string][]string{"Features": {"second feature", "remove option", "first feature"}, "Bug Fixes": {"first fix", "second fix"}}, false},
+		{"no version in range", sv.UpgradeNoteOptions{From: "3.0.0"}, nil, nil, nil, true},
+		{"invalid version", sv.UpgradeNoteOptions{From: "abc"}, nil, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReleaser(upgradeGit(), "").UpgradeNote(context.Background(), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReleaserImpl.UpgradeNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(releaseTags(got.Releases), tt.wantReleases) {
+				t.Errorf("ReleaserImpl.UpgradeNote() releases = %v, want %v", releaseTags(got.Releases), tt.wantReleases)
+			}
+			if got.ReleaseNote.Tag != tt.wantReleases[0] || got.BreakingChangesName != "Breaking Changes" {
+				t.Errorf("ReleaserImpl.UpgradeNote() tag = %v, breaking changes name = %v", got.ReleaseNote.Tag, got.BreakingChangesName)
+			}
+
+			var breaking []string
+			for _, bc := range got.BreakingChanges {
+				breaking = append(breaking, bc.Tag+": "+bc.Message)
+			}
+			if !reflect.DeepEqual(breaking, tt.wantBreaking) {
+				t.Errorf("ReleaserImpl.UpgradeNote() breaking changes = %v, want %v", breaking, tt.wantBreaking)
+			}
+
+			sections := make(map[string][]string)
+			for _, section := range got.ReleaseNote.Sections {
+				if s, ok := section.(sv.ReleaseNoteCommitsSection); ok {
+					sections[s.Name] = descriptions(s.Items)
+				}
+			}
+			if !reflect.DeepEqual(sections, tt.wantSections) {
+				t.Errorf("ReleaserImpl.UpgradeNote() sections = %v, want %v", sections, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestReleaserImpl_Versions(t *testing.T) {
+	pattern := "v%d.%d.%d"
+	filter := ""
+	g := svtest.NewGit(sv.NewMessageProcessor(releaserMessageCfg, sv.BranchesConfig{}), sv.TagConfig{Pattern: &pattern, Filter: &filter}, sv.LogConfig{})
+	for _, tag := range []string{"v1.0.0", "v1.2.0", "v2.0.0", "latest", "v1.2.1", "v1.10.0", "v2.1.0"} {
+		g.AddCommit("fix: " + tag)
+		g.AddTag(tag)
+	}
+
+	tests := []struct {
+		name string
+		opts sv.VersionsOptions
+		want []string
+	}{
+		{"all versions", sv.VersionsOptions{}, []string{"v1.0.0", "v1.2.0", "v1.2.1", "v1.10.0", "v2.0.0", "v2.1.0"}},
+		{"constraint", sv.VersionsOptions{Constraint: versionConstraint(t, "^1.2")}, []string{"v1.2.0", "v1.2.1", "v1.10.0"}},
+		{"latest per major", sv.VersionsOptions{LatestPerMajor: true}, []string{"v1.10.0", "v2.1.0"}},
+		{"latest per minor", sv.VersionsOptions{LatestPerMinor: true}, []string{"v1.0.0", "v1.2.1", "v1.10.0", "v2.0.0", "v2.1.0"}},
+		{"constraint and latest per major", sv.VersionsOptions{Constraint: versionConstraint(t, "<1.10"), LatestPerMajor: true}, []string{"v1.2.1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReleaser(g, "").Versions(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("ReleaserImpl.Versions() error = %v", err)
+			}
+			var tags []string
+			for _, v := range got {
+				tags = append(tags, v.Tag)
+				if v.Hash == "" || v.Date.IsZero() {
+					t.Errorf("ReleaserImpl.Versions() tag %s without hash or date", v.Tag)
+				}
+			}
+			if !reflect.DeepEqual(tags, tt.want) {
+				t.Errorf("ReleaserImpl.Versions() = %v, want %v", tags, tt.want)
+			}
+		})
+	}
+}
+
+type legacyGit struct {
+	sv.Git
+}
+
+func TestReleaserImpl_ChangelogMerge(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found on PATH")
+	}
+
+	r := svtest.NewRepo(t)
+	r.Commit("feat: first feature")
+	r.Tag("v1.0.0")
+	r.Branch("side").Commit("fix: side fix written early")
+	r.Checkout("main").Commit("feat: second feature")
+	r.Tag("v1.1.0")
+	r.Merge("side", "Merge branch 'side'")
+	r.Tag("v1.2.0")
+
+	pattern := "v%d.%d.%d"
+	filter := ""
+	for name, lcfg := range map[string]sv.LogConfig{"include merges": {Lenient: true}, "skip merges": {Merges: sv.LogMergesSkip}} {
+		t.Run(name, func(t *testing.T) {
+			cache, _ := sv.NewCache(t.TempDir(), "config")
+			releaser := newReleaser(r.Git(sv.NewMessageProcessor(releaserMessageCfg, sv.BranchesConfig{}), sv.TagConfig{Pattern: &pattern, Filter: &filter}, lcfg), "").WithCache(cache)
+			sideFix := []string{"side fix written early"}
+			if lcfg.Merges != sv.LogMergesSkip {
+				sideFix = []string{"Merge branch 'side'", "side fix written early"}
+			}
+
+			got, err := releaser.Changelog(context.Background(), sv.ChangelogOptions{All: true})
+			if err != nil {
+				t.Fatalf("ReleaserImpl.Changelog() error = %v", err)
+			}
+			want := map[string][]string{"v1.2.0": sideFix, "v1.1.0": {"second feature"}, "v1.0.0": {"first feature"}}
+			for _, release := range got {
+				if !reflect.DeepEqual(descriptions(release.Commits), want[release.ReleaseNote.Tag]) {
+					t.Errorf("ReleaserImpl.Changelog() %s commits = %v, want %v", release.ReleaseNote.Tag, descriptions(release.Commits), want[release.ReleaseNote.Tag])
+				}
+			}
+
+			note, err := releaser.UpgradeNote(context.Background(), sv.UpgradeNoteOptions{From: "1.0.0", To: "1.1.0"})
+			if err != nil {
+				t.Fatalf("ReleaserImpl.UpgradeNote() error = %v", err)
+			}
+			if len(note.Releases) != 1 || !reflect.DeepEqual(descriptions(note.Releases[0].Commits), []string{"second feature"}) {
+				t.Errorf("ReleaserImpl.UpgradeNote() releases = %+v, want v1.1.0 with second feature", note.Releases)
+			}
+		})
+	}
+}
+
+func TestReleaserImpl_canceledContext(t *testing.T) {
+	pattern := "v%d.%d.%d"
+	filter := ""
+	gits := map[string]sv.Git{"in-memory git": releaserGit(""), "git without context": legacyGit{releaserGit("")}}
+	if _, err := exec.LookPath("git"); err == nil {
+		r := svtest.NewRepo(t)
+		r.Commit("feat: first feature")
+		r.Tag("v1.0.0")
+		gits["git command"] = r.Git(sv.NewMessageProcessor(releaserMessageCfg, sv.BranchesConfig{}), sv.TagConfig{Pattern: &pattern, Filter: &filter}, sv.LogConfig{})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for name, git := range gits {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newReleaser(git, "").NextVersion(ctx); !errors.Is(err, context.Canceled) {
+				t.Errorf("ReleaserImpl.NextVersion() error = %v, want context canceled", err)
+			}
+			if _, err := newReleaser(git, "").Changelog(ctx, sv.ChangelogOptions{All: true}); !errors.Is(err, context.Canceled) {
+				t.Errorf("ReleaserImpl.Changelog() error = %v, want context canceled", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package sv
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RuleSeverity severity of a commit message rule.
+type RuleSeverity string
+
+// Rule severities, rules with severity off are not checked.
+const (
+	RuleSeverityOff     RuleSeverity = "off"
+	RuleSeverityWarning RuleSeverity = "warning"
+	RuleSeverityError   RuleSeverity = "error"
+)
+
+// Commit message rules.
+const (
+	RuleHeaderFormat        = "header-format"
+	RuleTypeEnum            = "type-enum"
+	RuleScopeEnum           = "scope-enum"
+	RuleSubjectCase         = "subject-case"
+	RuleSubjectFullStop     = "subject-full-stop"
+	RuleHeaderMaxLength     = "header-max-length"
+	RuleScopeRequired       = "scope-required"
+	RuleScopeCase           = "scope-case"
+	RuleBodyLeadingBlank    = "body-leading-blank"
+	RuleBodyMaxLineLength   = "body-max-line-length"
+	RuleFooterMaxLineLength = "footer-max-line-length"
+	RuleBodyRequired        = "body-required"
+	RuleFooterRequired      = "footer-required"
+	RuleForbiddenWords      = "forbidden-words"
+)
+
+// Cases supported by subject-case and scope-case rules, subject-case only checks the first letter.
+const (
+	CaseLower    = "lower-case"
+	CaseUpper    = "upper-case"
+	CaseSentence = "sentence-case"
+	CaseKebab    = "kebab-case"
+	CaseSnake    = "snake-case"
+	CaseCamel    = "camel-case"
+	CasePascal   = "pascal-case"
+)
+
+var (
+	sentenceCaseRegex = regexp.MustCompile("^[A-Z].*$")
+	scopeCaseRegexes  = map[string]*regexp.Regexp{
+		CaseLower:  regexp.MustCompile(`^[^A-Z]*$`),
+		CaseUpper:  regexp.MustCompile(`^[^a-z]*$`),
+		CaseKebab:  regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+		CaseSnake:  regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`),
+		CaseCamel:  regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+		CasePascal: regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`),
+	}
+)
+
+// Commit message segments where a rule violation is found.
+const (
+	SegmentHeader      = "header"
+	SegmentType        = "type"
+	SegmentScope       = "scope"
+	SegmentDescription = "description"
+	SegmentBody        = "body"
+	SegmentFooter      = "footer"
+)
+
+// RuleViolation a commit message rule that was not satisfied.
+// Line and column start at 1 and are relative to message without comments, both are 0 if violation is about something missing.
+type RuleViolation struct {
+	Rule     string       `json:"rule"`
+	Severity RuleSeverity `json:"severity"`
+	Message  string       `json:"message"`
+	Segment  string       `json:"segment"`
+	Line     int          `json:"line,omitempty"`
+	Column   int          `json:"column,omitempty"`
+	// Suggestion replacement for violation segment, eg.: "feat" for a type-enum violation with type "feature".
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Error return violation message.
+func (v RuleViolation) Error() string {
+	return v.Message
+}
+
+// lintMessage commit message split in parts used by rules.
+type lintMessage struct {
+	header            string
+	body              string
+	bodyLines         []string // Lines after header until footers, first line should be blank.
+	footerLines       []string
+	validHeader       bool
+	typeColumn        int
+	scopeColumn       int // Column of scope first character, or where it should be if there is no scope.
+	descriptionColumn int
+	msg               CommitMessage
+}
+
+// bodyLine message line number of a body line index.
+func (m lintMessage) bodyLine(index int) int {
+	return index + 2
+}
+
+// footerLine message line number of a footer line index.
+func (m lintMessage) footerLine(index int) int {
+	return len(m.bodyLines) + index + 2
+}
+
+type commitMessageRule struct {
+	id         string
+	defaultCfg CommitMessageRuleConfig
+	header     bool // Rule depends on parsed header and is skipped if header is invalid.
+	check      func(p MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation
+}
+
+// commitMessageRules rules in the order they are checked.
+var commitMessageRules = []commitMessageRule{
+	{RuleHeaderFormat, CommitMessageRuleConfig{Severity: RuleSeverityError}, false, checkHeaderFormat},
+	{RuleTypeEnum, CommitMessageRuleConfig{Severity: RuleSeverityError}, true, checkTypeEnum},
+	{RuleScopeEnum, CommitMessageRuleConfig{Severity: RuleSeverityError}, true, checkScopeEnum},
+	{RuleSubjectCase, CommitMessageRuleConfig{Severity: RuleSeverityError, Case: CaseLower}, true, checkSubjectCase},
+	{RuleSubjectFullStop, CommitMessageRuleConfig{Severity: RuleSeverityOff}, true, checkSubjectFullStop},
+	{RuleHeaderMaxLength, CommitMessageRuleConfig{Severity: RuleSeverityOff, Length: 100}, false, checkHeaderMaxLength},
+	{RuleScopeRequired, CommitMessageRuleConfig{Severity: RuleSeverityOff}, true, checkScopeRequired},
+	{RuleScopeCase, CommitMessageRuleConfig{Severity: RuleSeverityOff, Case: CaseLower}, true, checkScopeCase},
+	{RuleBodyLeadingBlank, CommitMessageRuleConfig{Severity: RuleSeverityOff}, false, checkBodyLeadingBlank},
+	{RuleBodyMaxLineLength, CommitMessageRuleConfig{Severity: RuleSeverityOff, Length: 100}, false, checkBodyMaxLineLength},
+	{RuleFooterMaxLineLength, CommitMessageRuleConfig{Severity: RuleSeverityOff, Length: 100}, false, checkFooterMaxLineLength},
+	{RuleBodyRequired, CommitMessageRuleConfig{Severity: RuleSeverityOff}, false, checkBodyRequired},
+	{RuleFooterRequired, CommitMessageRuleConfig{Severity: RuleSeverityOff}, false, checkFooterRequired},
+	{RuleForbiddenWords, CommitMessageRuleConfig{Severity: RuleSeverityOff}, false, checkForbiddenWords},
+}
+
+// compileRules merge configured rules with rule defaults.
+func compileRules(cfg map[string]CommitMessageRuleConfig) (map[string]CommitMessageRuleConfig, error) {
+	result := make(map[string]CommitMessageRuleConfig)
+	for _, rule := range commitMessageRules {
+		result[rule.id] = rule.defaultCfg
+	}
+
+	for id, ruleCfg := range cfg {
+		defaultCfg, exists := result[id]
+		if !exists {
+			return nil, fmt.Errorf("unknown commit message rule: %s", id)
+		}
+		switch ruleCfg.Severity {
+		case "":
+			ruleCfg.Severity = RuleSeverityError
+		case RuleSeverityOff, RuleSeverityWarning, RuleSeverityError:
+		default:
+			return nil, fmt.Errorf("invalid severity %s on commit message rule %s, expected: %s, %s or %s", ruleCfg.Severity, id, RuleSeverityError, RuleSeverityWarning, RuleSeverityOff)
+		}
+		if ruleCfg.Length == 0 {
+			ruleCfg.Length = defaultCfg.Length
+		}
+		if ruleCfg.Case == "" {
+			ruleCfg.Case = defaultCfg.Case
+		}
+		if err := validateRuleCase(id, ruleCfg.Case); err != nil {
+			return nil, err
+		}
+		if id == RuleForbiddenWords {
+			ruleCfg.wordRegexes = forbiddenWordRegexes(ruleCfg.Words)
+		}
+		result[id] = ruleCfg
+	}
+	return result, nil
+}
+
+func validateRuleCase(id, value string) error {
+	switch id {
+	case RuleSubjectCase:
+		if value != CaseLower && value != CaseSentence {
+			return fmt.Errorf("invalid case %s on commit message rule %s, expected: %s or %s", value, id, CaseLower, CaseSentence)
+		}
+	case RuleScopeCase:
+		if _, exists := scopeCaseRegexes[value]; !exists {
+			return fmt.Errorf("invalid case %s on commit message rule %s, expected: %s, %s, %s, %s, %s or %s", value, id, CaseLower, CaseUpper, CaseKebab, CaseSnake, CaseCamel, CasePascal)
+		}
+	}
+	return nil
+}
+
+// Lint check commit message against all enabled rules, comments are ignored.
+// An error is returned only if message could not be parsed or rules config is invalid.
+func (p MessageProcessorImpl) Lint(message string) ([]RuleViolation, error) {
+	if p.rulesErr != nil {
+		return nil, p.rulesErr
+	}
+
+	subject, body := splitCommitMessageContent(stripCommentLines(removeCarriage(message)))
+	msg, err := p.Parse(subject, body)
+	if errors.Is(err, ErrNonConventionalMessage) { // header does not match header selector
+		if severity := p.rules[RuleHeaderFormat].Severity; severity != RuleSeverityOff {
+			return []RuleViolation{{Rule: RuleHeaderFormat, Severity: severity, Message: err.Error(), Segment: SegmentHeader, Line: 1, Column: 1}}, nil
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	footerStart, _ := splitFooterLines(lines)
+	m := lintMessage{
+		header:      subject,
+		body:        body,
+		bodyLines:   lines[:footerStart],
+		footerLines: lines[footerStart:],
+		msg:         msg,
+	}
+	if header, _ := p.prepareHeader(subject); p.isConventionalHeader(header) {
+		m.validHeader = true
+		offset := strings.Index(subject, header)
+		index := p.grammar.subject.FindStringSubmatchIndex(header)
+		typeStart, typeEnd := groupIndex(p.grammar.subject, index, typeGroupName)
+		scopeStart, _ := groupIndex(p.grammar.subject, index, scopeGroupName)
+		descriptionStart, _ := groupIndex(p.grammar.subject, index, descriptionGroupName)
+		m.typeColumn = runeColumn(subject, offset+typeStart)
+		m.scopeColumn = runeColumn(subject, offset+typeEnd)
+		if scopeStart >= 0 {
+			m.scopeColumn = runeColumn(subject, offset+scopeStart)
+		}
+		m.descriptionColumn = runeColumn(subject, offset+descriptionStart+len(header[descriptionStart:])-len(strings.TrimLeft(header[descriptionStart:], " ")))
+	}
+
+	var violations []RuleViolation
+	for _, rule := range commitMessageRules {
+		cfg := p.rules[rule.id]
+		if cfg.Severity == RuleSeverityOff || (rule.header && !m.validHeader) || (len(cfg.Types) > 0 && !contains(msg.Type, cfg.Types)) {
+			continue
+		}
+		for _, v := range rule.check(p, cfg, m) {
+			v.Rule, v.Severity = rule.id, cfg.Severity
+			v.Suggestion = p.suggestion(v, m)
+			violations = append(violations, v)
+		}
+	}
+	return violations, nil
+}
+
+// runeColumn 1-based column of a byte index.
+func runeColumn(line string, index int) int {
+	return utf8.RuneCountInString(line[:index]) + 1
+}
+
+func checkHeaderFormat(_ MessageProcessorImpl, _ CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	if !m.validHeader {
+		return []RuleViolation{{Message: fmt.Sprintf("subject [%s] should be valid according with conventional commits", m.header), Segment: SegmentHeader, Line: 1, Column: 1}}
+	}
+	return nil
+}
+
+func checkTypeEnum(p MessageProcessorImpl, _ CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	return headerViolations(p.validateType(m.msg.Type), m.typeColumn)
+}
+
+func checkScopeEnum(p MessageProcessorImpl, _ CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	return headerViolations(p.validateScope(m.msg.Scope), m.scopeColumn)
+}
+
+func checkSubjectCase(_ MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	return headerViolations(validateDescriptionCase(cfg.Case, m.msg.Description), m.descriptionColumn)
+}
+
+func checkSubjectFullStop(_ MessageProcessorImpl, _ CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	if strings.HasSuffix(m.msg.Description, ".") {
+		return []RuleViolation{{Message: fmt.Sprintf("description [%s] should not end with a period", m.msg.Description), Segment: SegmentDescription, Line: 1, Column: runeColumn(m.header, strings.LastIndex(m.header, "."))}}
+	}
+	return nil
+}
+
+func checkHeaderMaxLength(_ MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	if length := utf8.RuneCountInString(m.header); length > cfg.Length {
+		return []RuleViolation{{Message: fmt.Sprintf("header should have at most %d characters, current: %d", cfg.Length, length), Segment: SegmentHeader, Line: 1, Column: cfg.Length + 1}}
+	}
+	return nil
+}
+
+func checkScopeRequired(_ MessageProcessorImpl, _ CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	if len(m.msg.Scopes) == 0 {
+		return []RuleViolation{{Message: "message scope is required", Segment: SegmentScope, Line: 1, Column: m.scopeColumn}}
+	}
+	return nil
+}
+
+func checkScopeCase(_ MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	for _, scope := range m.msg.Scopes {
+		if !scopeCaseRegexes[cfg.Case].MatchString(scope) {
+			return []RuleViolation{{Message: fmt.Sprintf("scope [%s] should be %s", scope, cfg.Case), Segment: SegmentScope, Line: 1, Column: m.scopeColumn}}
+		}
+	}
+	return nil
+}
+
+func checkBodyLeadingBlank(_ MessageProcessorImpl, _ CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	// body lines are empty if a footer follows header directly.
+	if firstLine, _, _ := strings.Cut(m.body, "\n"); m.body != "" && strings.TrimSpace(firstLine) != "" {
+		return []RuleViolation{{Message: "body should begin with a blank line", Segment: m.lineSegment(2), Line: 2, Column: 1}}
+	}
+	return nil
+}
+
+func checkBodyMaxLineLength(_ MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	return maxLineLength(SegmentBody, cfg.Length, m.bodyLines, m.bodyLine)
+}
+
+func checkFooterMaxLineLength(_ MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	return maxLineLength(SegmentFooter, cfg.Length, m.footerLines, m.footerLine)
+}
+
+func maxLineLength(segment string, max int, lines []string, lineNumber func(int) int) []RuleViolation {
+	var result []RuleViolation
+	for i, line := range lines {
+		if length := utf8.RuneCountInString(line); length > max {
+			result = append(result, RuleViolation{Message: fmt.Sprintf("%s line %d should have at most %d characters, current: %d", segment, i+1, max, length), Segment: segment, Line: lineNumber(i), Column: max + 1})
+		}
+	}
+	return result
+}
+
+func checkBodyRequired(_ MessageProcessorImpl, _ CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	if strings.TrimSpace(strings.Join(m.bodyLines, "\n")) == "" {
+		return []RuleViolation{{Message: fmt.Sprintf("body is required for type %s", m.msg.Type), Segment: SegmentBody}}
+	}
+	return nil
+}
+
+func checkFooterRequired(_ MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	var result []RuleViolation
+	for _, key := range cfg.Footers {
+		if len(m.msg.Values(key)) == 0 && !hasFooterToken(m.msg.Footers, key) {
+			result = append(result, RuleViolation{Message: fmt.Sprintf("footer %s is required for type %s", key, m.msg.Type), Segment: SegmentFooter})
+		}
+	}
+	return result
+}
+
+func hasFooterToken(footers []CommitFooter, token string) bool {
+	for _, footer := range footers {
+		if footer.Token == token {
+			return true
+		}
+	}
+	return false
+}
+
+// forbiddenWordRegexes case insensitive regexes that match words not preceded or followed by letters, digits or underscore.
+// Unlike \b, it also works for words that start or end with other characters, eg.: "wip!" or "c++".
+func forbiddenWordRegexes(words []string) []*regexp.Regexp {
+	regexes := make([]*regexp.Regexp, len(words))
+	for i, word := range words {
+		regexes[i] = regexp.MustCompile(`(?i)(?:^|[^\pL\pN_])(` + regexp.QuoteMeta(word) + `)(?:[^\pL\pN_]|$)`)
+	}
+	return regexes
+}
+
+// checkForbiddenWords report first occurrence of each forbidden word.
+func checkForbiddenWords(_ MessageProcessorImpl, cfg CommitMessageRuleConfig, m lintMessage) []RuleViolation {
+	lines := append([]string{m.header}, strings.Split(m.body, "\n")...)
+	var result []RuleViolation
+	for i, regex := range cfg.wordRegexes {
+		for j, line := range lines {
+			if index := regex.FindStringSubmatchIndex(line); index != nil {
+				result = append(result, RuleViolation{Message: fmt.Sprintf("message should not contain forbidden word [%s]", cfg.Words[i]), Segment: m.lineSegment(j + 1), Line: j + 1, Column: runeColumn(line, index[2])})
+				break
+			}
+		}
+	}
+	return result
+}
+
+// lineSegment segment of a message line number.
+func (m lintMessage) lineSegment(line int) string {
+	switch {
+	case line == 1:
+		return SegmentHeader
+	case line < m.footerLine(0):
+		return SegmentBody
+	default:
+		return SegmentFooter
+	}
+}
+
+// headerViolations convert a header validation error to a violation on column.
+func headerViolations(err error, column int) []RuleViolation {
+	var v RuleViolation
+	if !errors.As(err, &v) {
+		return nil
+	}
+	v.Line, v.Column = 1, column
+	return []RuleViolation{v}
+}
+
+// CommitViolations rule violations found on a commit message.
+type CommitViolations struct {
+	Hash       string          `json:"hash,omitempty"` // Empty if message is not from a commit.
+	Subject    string          `json:"subject"`
+	Violations []RuleViolation `json:"violations,omitempty"`
+}
+
+// LintCommits check commit messages against rules, return a result for each validated commit, merge commits are ignored.
+// Raw message is checked if available, so rules like body-leading-blank can be verified.
+func LintCommits(p MessageProcessor, commits []GitCommitLog) ([]CommitViolations, error) {
+	var result []CommitViolations
+	for _, commit := range commits {
+		if len(commit.Parents) > 1 {
+			continue
+		}
+		message := commit.RawMessage
+		if message == "" {
+			message = commit.Subject + "\n\n" + commit.Message.Body
+		}
+		violations, err := p.Lint(strings.TrimSpace(message))
+		if err != nil {
+			return nil, fmt.Errorf("could not validate commit %s, error: %w", commit.Hash, err)
+		}
+		result = append(result, CommitViolations{Hash: commit.Hash, Subject: commit.Subject, Violations: violations})
+	}
+	return result, nil
+}
@@ -0,0 +1,198 @@
+package sv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func rulesCfg(rules map[string]CommitMessageRuleConfig) CommitMessageConfig {
+	cfg := ccfgWithScope
+	cfg.Rules = rules
+	return cfg
+}
+
+func violation(rule string, severity RuleSeverity, message, segment string, line, column int) RuleViolation {
+	return RuleViolation{Rule: rule, Severity: severity, Message: message, Segment: segment, Line: line, Column: column}
+}
+
+func withSuggestion(v RuleViolation, suggestion string) RuleViolation {
+	v.Suggestion = suggestion
+	return v
+}
+
+func TestMessageProcessorImpl_Lint(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]CommitMessageRuleConfig
+		message string
+		want    []RuleViolation
+		wantErr bool
+	}{
+		{"valid message", nil, "feat(scope): add something", nil, false},
+		{"invalid header skip header rules", nil, "Feat add something", []RuleViolation{violation(RuleHeaderFormat, RuleSeverityError, "subject [Feat add something] should be valid according with conventional commits", SegmentHeader, 1, 1)}, false},
+		{"default rules", nil, "other(invalid): Add something", []RuleViolation{
+			violation(RuleTypeEnum, RuleSeverityError, "message type should be one of [feat, fix]", SegmentType, 1, 1),
+			violation(RuleScopeEnum, RuleSeverityError, "message scope should one of [, scope]", SegmentScope, 1, 7),
+			withSuggestion(violation(RuleSubjectCase, RuleSeverityError, "description [Add something] should begins with lowercase letter", SegmentDescription, 1, 17), "add something"),
+		}, false},
+		{"rule as warning", map[string]CommitMessageRuleConfig{RuleSubjectCase: {Severity: RuleSeverityWarning}}, "feat: Add something", []RuleViolation{withSuggestion(violation(RuleSubjectCase, RuleSeverityWarning, "description [Add something] should begins with lowercase letter", SegmentDescription, 1, 7), "add something")}, false},
+		{"rule off", map[string]CommitMessageRuleConfig{RuleTypeEnum: {Severity: RuleSeverityOff}}, "other: add something", nil, false},
+		{"empty severity is error", map[string]CommitMessageRuleConfig{RuleSubjectFullStop: {}}, "feat: add something.", []RuleViolation{withSuggestion(violation(RuleSubjectFullStop, RuleSeverityError, "description [add something.] should not end with a period", SegmentDescription, 1, 20), "add something")}, false},
+		{"sentence case", map[string]CommitMessageRuleConfig{RuleSubjectCase: {Case: CaseSentence}}, "feat: add something", []RuleViolation{withSuggestion(violation(RuleSubjectCase, RuleSeverityError, "description [add something] should begins with uppercase letter", SegmentDescription, 1, 7), "Add something")}, false},
+		{"header max length", map[string]CommitMessageRuleConfig{RuleHeaderMaxLength: {Length: 10}}, "feat: add something", []RuleViolation{violation(RuleHeaderMaxLength, RuleSeverityError, "header should have at most 10 characters, current: 19", SegmentHeader, 1, 11)}, false},
+		{"body and footer max line length", map[string]CommitMessageRuleConfig{RuleBodyMaxLineLength: {Length: 5}, RuleFooterMaxLineLength: {Length: 8}}, "feat: add something\n\nbody\nlong body\n\njira: JIRA-123", []RuleViolation{
+			violation(RuleBodyMaxLineLength, RuleSeverityError, "body line 3 should have at most 5 characters, current: 9", SegmentBody, 4, 6),
+			violation(RuleFooterMaxLineLength, RuleSeverityError, "footer line 1 should have at most 8 characters, current: 14", SegmentFooter, 6, 9),
+		}, false},
+		{"body leading blank", map[string]CommitMessageRuleConfig{RuleBodyLeadingBlank: {}}, "feat: add something\nbody", []RuleViolation{violation(RuleBodyLeadingBlank, RuleSeverityError, "body should begin with a blank line", SegmentBody, 2, 1)}, false},
+		{"body leading blank with footer after header", map[string]CommitMessageRuleConfig{RuleBodyLeadingBlank: {}}, "feat: add something\nRefs: 1", []RuleViolation{violation(RuleBodyLeadingBlank, RuleSeverityError, "body should begin with a blank line", SegmentFooter, 2, 1)}, false},
+		{"scope required", map[string]CommitMessageRuleConfig{RuleScopeRequired: {}}, "feat: add something", []RuleViolation{violation(RuleScopeRequired, RuleSeverityError, "message scope is required", SegmentScope, 1, 5)}, false},
+		{"scope case", map[string]CommitMessageRuleConfig{RuleScopeEnum: {Severity: RuleSeverityOff}, RuleScopeCase: {Case: CaseKebab}}, "feat(my_scope): add something", []RuleViolation{violation(RuleScopeCase, RuleSeverityError, "scope [my_scope] should be kebab-case", SegmentScope, 1, 6)}, false},
+		{"body required for type", map[string]CommitMessageRuleConfig{RuleBodyRequired: {Types: []string{"feat"}}}, "feat: add something\n\njira: JIRA-123", []RuleViolation{violation(RuleBodyRequired, RuleSeverityError, "body is required for type feat", SegmentBody, 0, 0)}, false},
+		{"body required ignore other types", map[string]CommitMessageRuleConfig{RuleBodyRequired: {Types: []string{"feat"}}}, "fix: add something", nil, false},
+		{"footer required", map[string]CommitMessageRuleConfig{RuleFooterRequired: {Footers: []string{"issue", "Reviewed-by"}}}, "feat: add something\n\njira: JIRA-123", []RuleViolation{violation(RuleFooterRequired, RuleSeverityError, "footer Reviewed-by is required for type feat", SegmentFooter, 0, 0)}, false},
+		{"forbidden words", map[string]CommitMessageRuleConfig{RuleForbiddenWords: {Words: []string{"wip", "todo"}}}, "feat: add something\n\nbody\nsome WIP on workflow", []RuleViolation{violation(RuleForbiddenWords, RuleSeverityError, "message should not contain forbidden word [wip]", SegmentBody, 4, 6)}, false},
+		{"forbidden words with symbols", map[string]CommitMessageRuleConfig{RuleForbiddenWords: {Words: []string{"wip!", "c++"}}}, "feat: add something\n\nabc++ and wip!s\nsome C++ code, wip!", []RuleViolation{violation(RuleForbiddenWords, RuleSeverityError, "message should not contain forbidden word [wip!]", SegmentBody, 4, 16), violation(RuleForbiddenWords, RuleSeverityError, "message should not contain forbidden word [c++]", SegmentBody, 4, 6)}, false},
+		{"ignore comments", map[string]CommitMessageRuleConfig{RuleForbiddenWords: {Words: []string{"wip"}}}, "feat: add something\n# wip", nil, false},
+		{"unknown rule", map[string]CommitMessageRuleConfig{"unknown": {}}, "feat: add something", nil, true},
+		{"invalid severity", map[string]CommitMessageRuleConfig{RuleTypeEnum: {Severity: "fatal"}}, "feat: add something", nil, true},
+		{"invalid case", map[string]CommitMessageRuleConfig{RuleScopeCase: {Case: "title-case"}}, "feat: add something", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewMessageProcessor(rulesCfg(tt.rules), newBranchCfg(false))
+			got, err := p.Lint(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MessageProcessorImpl.Lint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MessageProcessorImpl.Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageProcessorImpl_LintHeaderGrammar(t *testing.T) {
+	scopeCase := ccfgHeaderGrammar
+	scopeCase.Rules = map[string]CommitMessageRuleConfig{RuleScopeEnum: {Severity: RuleSeverityOff}, RuleScopeCase: {Case: CaseKebab}}
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		message string
+		want    []RuleViolation
+	}{
+		{"type pattern", ccfgHeaderGrammar, "I18n(ui): add something", nil},
+		{"invalid type with type pattern", ccfgHeaderGrammar, "l10n(ui): add something", []RuleViolation{withSuggestion(violation(RuleTypeEnum, RuleSeverityError, "message type should be one of [feat, fix, i18n, deps]", SegmentType, 1, 1), "i18n")}},
+		{"invalid scope of multiple scopes", ccfgHeaderGrammar, "feat(api,db): add something", []RuleViolation{violation(RuleScopeEnum, RuleSeverityError, "message scope should one of [, api, ui]", SegmentScope, 1, 6)}},
+		{"scope case of multiple scopes", scopeCase, "feat(my-api, my_ui): add something", []RuleViolation{violation(RuleScopeCase, RuleSeverityError, "scope [my_ui] should be kebab-case", SegmentScope, 1, 6)}},
+		{"invalid scope pattern", CommitMessageConfig{Types: []string{"feat"}, Scope: CommitMessageScopeConfig{Pattern: "[a-z]+"}}, "feat(api-1): add something", []RuleViolation{violation(RuleHeaderFormat, RuleSeverityError, "subject [feat(api-1): add something] should be valid according with conventional commits", SegmentHeader, 1, 1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMessageProcessor(tt.cfg, newBranchCfg(false)).Lint(tt.message)
+			if err != nil {
+				t.Errorf("MessageProcessorImpl.Lint() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MessageProcessorImpl.Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageProcessorImpl_ValidateWithRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]CommitMessageRuleConfig
+		message string
+		wantErr string
+	}{
+		{"warning is not an error", map[string]CommitMessageRuleConfig{RuleSubjectCase: {Severity: RuleSeverityWarning}}, "feat: Add something", ""},
+		{"first error", map[string]CommitMessageRuleConfig{RuleSubjectCase: {Severity: RuleSeverityWarning}, RuleScopeRequired: {}}, "other: Add something", "message type should be one of [feat, fix]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewMessageProcessor(rulesCfg(tt.rules), newBranchCfg(false))
+			err := p.Validate(tt.message)
+			if (err == nil && tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) || (err != nil && tt.wantErr == "") {
+				t.Errorf("MessageProcessorImpl.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLintCommits(t *testing.T) {
+	commit := func(hash, subject, body string, parents ...string) GitCommitLog {
+		return GitCommitLog{Hash: hash, Subject: subject, Parents: parents, Message: CommitMessage{Body: body}}
+	}
+	tests := []struct {
+		name    string
+		cfg     CommitMessageConfig
+		commits []GitCommitLog
+		want    []CommitViolations
+		wantErr bool
+	}{
+		{"valid commits", ccfg, []GitCommitLog{commit("a1", "feat: add something", ""), commit("b2", "fix: fix something", "body")}, []CommitViolations{{Hash: "a1", Subject: "feat: add something"}, {Hash: "b2", Subject: "fix: fix something"}}, false},
+		{"invalid commits", ccfg, []GitCommitLog{commit("a1", "feat: add something", ""), commit("b2", "Fix something", ""), commit("c3", "other: Fix something", "")}, []CommitViolations{
+			{Hash: "a1", Subject: "feat: add something"},
+			{Hash: "b2", Subject: "Fix something", Violations: []RuleViolation{violation(RuleHeaderFormat, RuleSeverityError, "subject [Fix something] should be valid according with conventional commits", SegmentHeader, 1, 1)}},
+			{Hash: "c3", Subject: "other: Fix something", Violations: []RuleViolation{
+				violation(RuleTypeEnum, RuleSeverityError, "message type should be one of [feat, fix]", SegmentType, 1, 1),
+				withSuggestion(violation(RuleSubjectCase, RuleSeverityError, "description [Fix something] should begins with lowercase letter", SegmentDescription, 1, 8), "fix something"),
+			}},
+		}, false},
+		{"ignore merge commits", ccfg, []GitCommitLog{commit("a1", "Merge branch 'x'", "", "p1", "p2")}, nil, false},
+		{"body rules", rulesCfg(map[string]CommitMessageRuleConfig{RuleForbiddenWords: {Words: []string{"wip"}}}), []GitCommitLog{commit("a1", "feat: add something", "wip")}, []CommitViolations{
+			{Hash: "a1", Subject: "feat: add something", Violations: []RuleViolation{violation(RuleForbiddenWords, RuleSeverityError, "message should not contain forbidden word [wip]", SegmentBody, 3, 1)}},
+		}, false},
+		{"raw message", rulesCfg(map[string]CommitMessageRuleConfig{RuleBodyLeadingBlank: {}}), []GitCommitLog{{Hash: "a1", Subject: "feat: add something", RawMessage: "feat: add something\nbody\n", Message: CommitMessage{Body: "body"}}}, []CommitViolations{
+			{Hash: "a1", Subject: "feat: add something", Violations: []RuleViolation{violation(RuleBodyLeadingBlank, RuleSeverityError, "body should begin with a blank line", SegmentBody, 2, 1)}},
+		}, false},
+		{"header selector", newCommitMessageCfg("Merged PR (\\d+): (?P<header>.*)"), []GitCommitLog{commit("a1", "Merged PR 1: feat: add something", ""), commit("b2", "feat: add something", "")}, []CommitViolations{
+			{Hash: "a1", Subject: "Merged PR 1: feat: add something"},
+			{Hash: "b2", Subject: "feat: add something", Violations: []RuleViolation{violation(RuleHeaderFormat, RuleSeverityError, "non-conventional commit message, could not find header regex group in match result for 'feat: add something'", SegmentHeader, 1, 1)}},
+		}, false},
+		{"invalid rules", rulesCfg(map[string]CommitMessageRuleConfig{"unknown": {}}), []GitCommitLog{commit("a1", "feat: add something", "")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LintCommits(NewMessageProcessor(tt.cfg, newBranchCfg(false)), tt.commits)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LintCommits() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageProcessorImpl_ValidateTypedErrors(t *testing.T) {
+	p := NewMessageProcessor(ccfgWithScope, newBranchCfg(false))
+	tests := []struct {
+		name string
+		err  error
+		want RuleViolation
+	}{
+		{"type", p.ValidateType("other"), violation(RuleTypeEnum, RuleSeverityError, "message type should be one of [feat, fix]", SegmentType, 0, 0)},
+		{"scope", p.ValidateScope("other"), violation(RuleScopeEnum, RuleSeverityError, "message scope should one of [, scope]", SegmentScope, 0, 0)},
+		{"description", p.ValidateDescription("Other"), violation(RuleSubjectCase, RuleSeverityError, "description [Other] should begins with lowercase letter", SegmentDescription, 0, 0)},
+		{"message", p.Validate("feat(scope): Other"), withSuggestion(violation(RuleSubjectCase, RuleSeverityError, "description [Other] should begins with lowercase letter", SegmentDescription, 1, 14), "other")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got RuleViolation
+			if !errors.As(tt.err, &got) {
+				t.Fatalf("error = %v, want RuleViolation", tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("error = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
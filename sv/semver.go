@@ -1,6 +1,11 @@
 package sv
 
-import "github.com/Masterminds/semver/v3"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
 
 type versionType int
 
@@ -26,6 +31,36 @@ func ToVersion(value string) (*semver.Version, error) {
 	return semver.NewVersion(version)
 }
 
+// NewVersionConstraint create a constraint that matches versions from start to end, both included and optional.
+// Start can also be a semver constraint, eg.: ">=1.2 <2", in this case end must be empty.
+func NewVersionConstraint(start, end string) (*semver.Constraints, error) {
+	if start != "" && !IsValidVersion(start) {
+		if end != "" {
+			return nil, fmt.Errorf("invalid version range: end version %s cannot be used with constraint %s", end, start)
+		}
+		constraint, err := semver.NewConstraint(start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint: %s, error: %v", start, err)
+		}
+		return constraint, nil
+	}
+	if end != "" && !IsValidVersion(end) {
+		return nil, fmt.Errorf("invalid end version: %s", end)
+	}
+
+	var constraints []string
+	if start != "" {
+		constraints = append(constraints, ">= "+start)
+	}
+	if end != "" {
+		constraints = append(constraints, "<= "+end)
+	}
+	if len(constraints) == 0 {
+		constraints = append(constraints, "*")
+	}
+	return semver.NewConstraint(strings.Join(constraints, ", "))
+}
+
 // SemVerCommitsProcessor interface.
 type SemVerCommitsProcessor interface {
 	NextVersion(version *semver.Version, commits []GitCommitLog) (*semver.Version, bool)
@@ -85,6 +120,12 @@ func (p SemVerCommitsProcessorImpl) versionTypeToUpdate(commit GitCommitLog) ver
 	if commit.Message.IsBreakingChange {
 		return major
 	}
+	if commit.Message.NonConventional {
+		if p.IncludeUnknownTypeAsPatch {
+			return patch
+		}
+		return none
+	}
 	if _, exists := p.MajorVersionTypes[commit.Message.Type]; exists {
 		return major
 	}
@@ -25,6 +25,8 @@ func TestSemVerCommitsProcessorImpl_NextVersion(t *testing.T) {
 		{"patch update without version", false, nil, []GitCommitLog{commitlog("patch", map[string]string{}, "a")}, nil, true},
 		{"minor update", false, version("0.0.0"), []GitCommitLog{commitlog("patch", map[string]string{}, "a"), commitlog("minor", map[string]string{}, "a")}, version("0.1.0"), true},
 		{"major update", false, version("0.0.0"), []GitCommitLog{commitlog("patch", map[string]string{}, "a"), commitlog("major", map[string]string{}, "a")}, version("1.0.0"), true},
+		{"non-conventional update patch", false, version("0.0.0"), []GitCommitLog{nonConventionalCommitlog("a")}, version("0.0.1"), true},
+		{"non-conventional ignored", true, version("0.0.0"), []GitCommitLog{nonConventionalCommitlog("a")}, version("0.0.0"), false},
 		{"breaking change update", false, version("0.0.0"), []GitCommitLog{commitlog("patch", map[string]string{}, "a"), commitlog("patch", map[string]string{"breaking-change": "break"}, "a")}, version("1.0.0"), true},
 	}
 	for _, tt := range tests {
@@ -95,3 +97,42 @@ func TestIsValidVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestNewVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		matches   []string
+		unmatches []string
+		wantErr   bool
+	}{
+		{"start and end", "1.2.0", "2.0.0", []string{"1.2.0", "1.9.9", "2.0.0"}, []string{"1.1.9", "2.0.1"}, false},
+		{"start only", "1.2", "", []string{"1.2.0", "3.0.0"}, []string{"1.1.0"}, false},
+		{"end only", "", "2.0.0", []string{"0.1.0", "2.0.0"}, []string{"2.1.0"}, false},
+		{"any version", "", "", []string{"0.1.0", "2.0.0"}, nil, false},
+		{"constraint", ">=1.2 <2", "", []string{"1.2.0", "1.9.0"}, []string{"1.1.0", "2.0.0"}, false},
+		{"constraint with end", ">=1.2", "2.0.0", nil, nil, true},
+		{"invalid constraint", ">=abc", "", nil, nil, true},
+		{"invalid end", "1.0.0", "abc", nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewVersionConstraint(tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewVersionConstraint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, v := range tt.matches {
+				if !got.Check(version(v)) {
+					t.Errorf("NewVersionConstraint() %s does not match %s", got, v)
+				}
+			}
+			for _, v := range tt.unmatches {
+				if got.Check(version(v)) {
+					t.Errorf("NewVersionConstraint() %s matches %s", got, v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+// Package svtest provides helpers to test code that uses sv package: an in-memory sv.Git implementation
+// and a builder for temporary git repositories.
+package svtest
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bvieira/sv4git/v2/sv"
+)
+
+const (
+	defaultAuthorName  = "sv4git"
+	defaultAuthorEmail = "sv4git@example.com"
+	defaultBranch      = "main"
+)
+
+// DefaultDate initial date used by Git and Repo, every commit or tag moves the clock forward by one minute.
+var DefaultDate = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type commit struct {
+	hash        string
+	subject     string
+	body        string
+	raw         string
+	authorName  string
+	authorEmail string
+	date        time.Time
+}
+
+type tag struct {
+	name   string
+	commit int
+	date   time.Time
+}
+
+// Git in-memory sv.GitContext implementation with a linear history.
+type Git struct {
+	messageProcessor sv.MessageProcessor
+	tagCfg           sv.TagConfig
+	logCfg           sv.LogConfig
+	now              time.Time
+	authorName       string
+	authorEmail      string
+	branch           string
+	detached         bool
+	commits          []commit
+	tags             []tag
+}
+
+// NewGit Git constructor, only lenient option is used from log config since history is linear.
+func NewGit(messageProcessor sv.MessageProcessor, tcfg sv.TagConfig, lcfg sv.LogConfig) *Git {
+	return &Git{
+		messageProcessor: messageProcessor,
+		tagCfg:           tcfg,
+		logCfg:           lcfg,
+		now:              DefaultDate,
+		authorName:       defaultAuthorName,
+		authorEmail:      defaultAuthorEmail,
+		branch:           defaultBranch,
+	}
+}
+
+// SetDate set date used by next commit or tag.
+func (g *Git) SetDate(date time.Time) *Git {
+	g.now = date
+	return g
+}
+
+// SetAuthor set author used by next commits.
+func (g *Git) SetAuthor(name, email string) *Git {
+	g.authorName, g.authorEmail = name, email
+	return g
+}
+
+// SetBranch set current branch name and leave detached state.
+func (g *Git) SetBranch(name string) *Git {
+	g.branch, g.detached = name, false
+	return g
+}
+
+// SetDetached set detached HEAD state.
+func (g *Git) SetDetached(detached bool) *Git {
+	g.detached = detached
+	return g
+}
+
+// AddCommit add a commit on top of history and return its hash, first line of message is used as subject.
+func (g *Git) AddCommit(message string) string {
+	subject, body, _ := strings.Cut(message, "\n")
+	return g.addCommit(subject, strings.TrimSpace(body), message)
+}
+
+// AddTag create a tag on last commit, like git, tag is not created if there are no commits.
+func (g *Git) AddTag(name string) *Git {
+	if len(g.commits) == 0 {
+		return g
+	}
+	g.tags = append(g.tags, tag{name: name, commit: len(g.commits) - 1, date: g.tick()})
+	return g
+}
+
+// LastTag get last created tag that matches tag filter, if no tag found, return empty.
+func (g *Git) LastTag() string {
+	tag, _ := g.LastTagContext(context.Background())
+	return tag
+}
+
+// LastTagContext get last created tag that matches tag filter, if no tag found, return empty.
+func (g *Git) LastTagContext(ctx context.Context) (string, error) {
+	tags, err := g.TagsContext(ctx)
+	if err != nil || len(tags) == 0 {
+		return "", err
+	}
+	return tags[len(tags)-1].Name, nil
+}
+
+// Log return commits from range, newest first.
+func (g *Git) Log(lr sv.LogRange) ([]sv.GitCommitLog, error) {
+	return g.LogContext(context.Background(), lr)
+}
+
+// LogContext return commits from range, newest first.
+func (g *Git) LogContext(ctx context.Context, lr sv.LogRange) ([]sv.GitCommitLog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	from, to, err := g.logBounds(lr)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []sv.GitCommitLog
+	for i := to; i >= from; i-- {
+		c := g.commits[i]
+		if lr.Type() == sv.DateRange && !inDateRange(c.date, lr.Start(), lr.End()) {
+			continue
+		}
+		log, err := g.commitLog(i)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse commit %s: %w", c.hash, err)
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+// Commit add a commit on top of history.
+func (g *Git) Commit(header, body, footer string) error {
+	return g.CommitContext(context.Background(), header, body, footer)
+}
+
+// CommitContext add a commit on top of history.
+func (g *Git) CommitContext(ctx context.Context, header, body, footer string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	body = strings.TrimSpace(body + "\n\n" + footer)
+	g.addCommit(header, body, strings.TrimSpace(header+"\n\n"+body))
+	return nil
+}
+
+// Tag create a tag using tag pattern on last commit.
+func (g *Git) Tag(version semver.Version) (string, error) {
+	return g.TagContext(context.Background(), version)
+}
+
+// TagContext create a tag using tag pattern on last commit.
+func (g *Git) TagContext(ctx context.Context, version semver.Version) (string, error) {
+	name := g.tagCfg.TagName(version)
+	if err := ctx.Err(); err != nil {
+		return name, err
+	}
+	if len(g.commits) == 0 {
+		return name, errors.New("could not create tag, there are no commits")
+	}
+	if g.tagIndex(name) >= 0 {
+		return name, fmt.Errorf("tag %s already exists", name)
+	}
+	g.AddTag(name)
+	return name, nil
+}
+
+// Tags list tags that matches tag filter sorted by creation date.
+func (g *Git) Tags() ([]sv.GitTag, error) {
+	return g.TagsContext(context.Background())
+}
+
+// TagsContext list tags that matches tag filter sorted by creation date.
+func (g *Git) TagsContext(ctx context.Context) ([]sv.GitTag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	filter := "*"
+	if g.tagCfg.Filter != nil && *g.tagCfg.Filter != "" {
+		filter = *g.tagCfg.Filter
+	}
+
+	var result []sv.GitTag
+	for _, t := range g.sortedTags() {
+		if matched, _ := path.Match(filter, t.name); matched {
+			result = append(result, sv.GitTag{Name: t.name, Date: t.date, Hash: g.commits[t.commit].hash})
+		}
+	}
+	return result, nil
+}
+
+// Branch get current branch, return empty if detached.
+func (g *Git) Branch() string {
+	return g.BranchContext(context.Background())
+}
+
+// BranchContext get current branch, return empty if detached.
+func (g *Git) BranchContext(ctx context.Context) string {
+	if g.detached {
+		return ""
+	}
+	return g.branch
+}
+
+// IsDetached check if HEAD is detached.
+func (g *Git) IsDetached() (bool, error) {
+	return g.IsDetachedContext(context.Background())
+}
+
+// IsDetachedContext check if HEAD is detached.
+func (g *Git) IsDetachedContext(ctx context.Context) (bool, error) {
+	return g.detached, ctx.Err()
+}
+
+func (g *Git) addCommit(subject, body, raw string) string {
+	date := g.tick()
+	sum := sha1.Sum([]byte(strconv.Itoa(len(g.commits)) + subject + body + date.String()))
+	hash := hex.EncodeToString(sum[:])
+	g.commits = append(g.commits, commit{hash: hash, subject: subject, body: body, raw: raw, authorName: g.authorName, authorEmail: g.authorEmail, date: date})
+	return hash
+}
+
+func (g *Git) tick() time.Time {
+	date := g.now
+	g.now = g.now.Add(time.Minute)
+	return date
+}
+
+func (g *Git) sortedTags() []tag {
+	tags := make([]tag, len(g.tags))
+	copy(tags, g.tags)
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].date.Before(tags[j].date)
+	})
+	return tags
+}
+
+func (g *Git) tagIndex(name string) int {
+	for i, t := range g.tags {
+		if t.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Git) logBounds(lr sv.LogRange) (int, int, error) {
+	from, to := 0, len(g.commits)-1
+	if lr.Type() == sv.DateRange || (lr.Start() == "" && lr.End() == "") {
+		return from, to, nil
+	}
+
+	if lr.End() != "" {
+		end, err := g.resolve(lr.End())
+		if err != nil {
+			return 0, 0, err
+		}
+		to = end
+	}
+	if lr.Start() != "" {
+		start, err := g.resolve(lr.Start())
+		if err != nil {
+			return 0, 0, err
+		}
+		from = start + 1
+	}
+	return from, to, nil
+}
+
+func (g *Git) resolve(revision string) (int, error) {
+	if revision == "HEAD" && len(g.commits) > 0 {
+		return len(g.commits) - 1, nil
+	}
+	if i := g.tagIndex(revision); i >= 0 {
+		return g.tags[i].commit, nil
+	}
+	for i, c := range g.commits {
+		if len(revision) >= 4 && strings.HasPrefix(c.hash, revision) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown revision: %s", revision)
+}
+
+func (g *Git) commitLog(index int) (sv.GitCommitLog, error) {
+	c := g.commits[index]
+	message, err := g.messageProcessor.Parse(c.subject, c.body)
+	if g.logCfg.Lenient && errors.Is(err, sv.ErrNonConventionalMessage) {
+		message, err = sv.NewNonConventionalCommitMessage(c.subject, c.body), nil
+	}
+	if g.logCfg.Lenient && err == nil && message.Type == "" {
+		message.NonConventional = true
+	}
+	if err != nil {
+		return sv.GitCommitLog{}, err
+	}
+
+	var parents []string
+	if index > 0 {
+		parents = []string{g.commits[index-1].hash}
+	}
+
+	return sv.GitCommitLog{
+		Date:           c.date.Format("2006-01-02"),
+		Timestamp:      int(c.date.Unix()),
+		AuthorName:     c.authorName,
+		AuthorEmail:    c.authorEmail,
+		AuthorDate:     c.date,
+		CommitterName:  c.authorName,
+		CommitterEmail: c.authorEmail,
+		CommitterDate:  c.date,
+		Hash:           c.hash[:7],
+		FullHash:       c.hash,
+		Parents:        parents,
+		Refs:           g.refs(index),
+		Subject:        c.subject,
+		RawMessage:     c.raw,
+		Message:        message,
+	}, nil
+}
+
+func (g *Git) refs(index int) []string {
+	var refs []string
+	if index == len(g.commits)-1 {
+		if g.detached {
+			refs = append(refs, "HEAD")
+		} else {
+			refs = append(refs, "HEAD -> "+g.branch)
+		}
+	}
+	for _, t := range g.tags {
+		if t.commit == index {
+			refs = append(refs, "tag: "+t.name)
+		}
+	}
+	return refs
+}
+
+func inDateRange(date time.Time, since, until string) bool {
+	day := date.Format("2006-01-02")
+	return (since == "" || day >= since) && (until == "" || day <= until)
+}
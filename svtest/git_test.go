@@ -0,0 +1,178 @@
+package svtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bvieira/sv4git/v2/sv"
+)
+
+func newGitSample() *Git {
+	g := NewGit(messageProcessor(), tagCfg("v%d.%d.%d", ""), sv.LogConfig{})
+	g.AddCommit("feat: first feature")
+	g.AddTag("v1.0.0")
+	g.SetDate(DefaultDate.AddDate(0, 0, 1))
+	g.AddCommit("fix: some fix\n\njira: JIRA-1")
+	g.SetAuthor("other", "other@example.com")
+	g.AddCommit("feat: second feature")
+	return g
+}
+
+func TestGit_Log(t *testing.T) {
+	tests := []struct {
+		name            string
+		lr              sv.LogRange
+		wantDescription []string
+		wantErr         bool
+	}{
+		{"all commits", sv.NewLogRange(sv.TagRange, "", ""), []string{"second feature", "some fix", "first feature"}, false},
+		{"since tag", sv.NewLogRange(sv.TagRange, "v1.0.0", ""), []string{"second feature", "some fix"}, false},
+		{"until tag", sv.NewLogRange(sv.TagRange, "", "v1.0.0"), []string{"first feature"}, false},
+		{"date range", sv.NewLogRange(sv.DateRange, "2020-01-02", "2020-01-02"), []string{"second feature", "some fix"}, false},
+		{"unknown revision", sv.NewLogRange(sv.HashRange, "unknown", ""), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newGitSample().Log(tt.lr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Git.Log() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var descriptions []string
+			for _, commit := range got {
+				descriptions = append(descriptions, commit.Message.Description)
+			}
+			if !reflect.DeepEqual(descriptions, tt.wantDescription) {
+				t.Errorf("Git.Log() descriptions = %v, want %v", descriptions, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestGit_commitLog(t *testing.T) {
+	g := newGitSample()
+	logs, err := g.Log(sv.NewLogRange(sv.TagRange, "v1.0.0", ""))
+	if err != nil {
+		t.Fatalf("Git.Log() error = %v", err)
+	}
+
+	if got := logs[0]; got.AuthorName != "other" || !reflect.DeepEqual(got.Refs, []string{"HEAD -> main"}) || got.Parents[0] != logs[1].FullHash {
+		t.Errorf("Git.Log() first commit = %+v", got)
+	}
+	if got := logs[1].Message.Metadata["issue"]; got != "JIRA-1" {
+		t.Errorf("Git.Log() issue = %v, want JIRA-1", got)
+	}
+	if got := logs[1].Date; got != "2020-01-02" {
+		t.Errorf("Git.Log() date = %v, want 2020-01-02", got)
+	}
+}
+
+func TestGit_Tag(t *testing.T) {
+	ctx := context.Background()
+	g := newGitSample()
+	semverProcessor := sv.NewSemVerCommitsProcessor(versioningCfg, messageCfg)
+
+	lastTag := g.LastTag()
+	commits, err := g.LogContext(ctx, sv.NewLogRange(sv.TagRange, lastTag, ""))
+	if err != nil {
+		t.Fatalf("Git.Log() error = %v", err)
+	}
+	current, _ := sv.ToVersion(lastTag)
+	next, updated := semverProcessor.NextVersion(current, commits)
+	if !updated || !next.Equal(semver.MustParse("1.1.0")) {
+		t.Fatalf("NextVersion() = %v, %v, want 1.1.0, true", next, updated)
+	}
+
+	tag, err := g.TagContext(ctx, *next)
+	if err != nil || tag != "v1.1.0" {
+		t.Fatalf("Git.Tag() = %v, %v, want v1.1.0", tag, err)
+	}
+	if _, err := g.TagContext(ctx, *next); err == nil {
+		t.Errorf("Git.Tag() duplicated tag error = nil")
+	}
+	if got := g.LastTag(); got != "v1.1.0" {
+		t.Errorf("Git.LastTag() = %v, want v1.1.0", got)
+	}
+	if commits, _ := g.LogContext(ctx, sv.NewLogRange(sv.TagRange, "v1.1.0", "")); len(commits) != 0 {
+		t.Errorf("Git.Log() after tag = %v, want empty", commits)
+	}
+}
+
+func TestGit_Tags(t *testing.T) {
+	g := newGitSample()
+	g.AddTag("other-1.0.0")
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []string
+	}{
+		{"no filter", "", []string{"v1.0.0", "other-1.0.0"}},
+		{"with filter", "v*", []string{"v1.0.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g.tagCfg = tagCfg("v%d.%d.%d", tt.filter)
+			tags, err := g.Tags()
+			if err != nil {
+				t.Fatalf("Git.Tags() error = %v", err)
+			}
+			var got []string
+			for _, tag := range tags {
+				got = append(got, tag.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Git.Tags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGit_releaseNotes(t *testing.T) {
+	ctx := context.Background()
+	g := newGitSample()
+	commits, err := g.LogContext(ctx, sv.NewLogRange(sv.TagRange, "v1.0.0", ""))
+	if err != nil {
+		t.Fatalf("Git.Log() error = %v", err)
+	}
+
+	rn := sv.NewReleaseNoteProcessor(releaseNotesCfg).Create(semver.MustParse("1.1.0"), "v1.1.0", DefaultDate, commits)
+	got, err := sv.NewOutputFormatter(templatesFS).FormatReleaseNote(rn)
+	if err != nil {
+		t.Fatalf("FormatReleaseNote() error = %v", err)
+	}
+
+	want := "## v1.1.0 (2020-01-01)\n\n### Features\n\n- second feature (" + commits[0].Hash + ")\n\n### Bug Fixes\n\n- some fix (" + commits[1].Hash + ") (JIRA-1)\n"
+	if got != want {
+		t.Errorf("FormatReleaseNote() = %v, want %v", got, want)
+	}
+}
+
+func TestGit_emptyHistory(t *testing.T) {
+	g := NewGit(messageProcessor(), tagCfg("v%d.%d.%d", ""), sv.LogConfig{}).AddTag("v1.0.0")
+
+	if tags, err := g.Tags(); err != nil || len(tags) != 0 {
+		t.Errorf("Git.Tags() = %v, %v, want empty", tags, err)
+	}
+	if got := g.LastTag(); got != "" {
+		t.Errorf("Git.LastTag() = %v, want empty", got)
+	}
+	if _, err := g.Tag(*semver.MustParse("1.0.0")); err == nil {
+		t.Errorf("Git.Tag() error = nil, want error without commits")
+	}
+}
+
+func TestGit_canceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := newGitSample().LogContext(ctx, sv.NewLogRange(sv.TagRange, "", "")); err == nil {
+		t.Errorf("Git.LogContext() error = nil, want context canceled")
+	}
+	if _, err := newGitSample().LastTagContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Git.LastTagContext() error = %v, want context canceled", err)
+	}
+}
@@ -0,0 +1,36 @@
+package svtest
+
+import (
+	"os"
+
+	"github.com/bvieira/sv4git/v2/sv"
+)
+
+var templatesFS = os.DirFS("../cmd/git-sv/resources/templates")
+
+var messageCfg = sv.CommitMessageConfig{
+	Types: []string{"feat", "fix", "chore"},
+	Footer: map[string]sv.CommitMessageFooterConfig{
+		"issue": {Key: "jira"},
+	},
+}
+
+var versioningCfg = sv.VersioningConfig{
+	UpdateMinor: []string{"feat"},
+	UpdatePatch: []string{"fix"},
+}
+
+var releaseNotesCfg = sv.ReleaseNotesConfig{
+	Sections: []sv.ReleaseNotesSectionConfig{
+		{Name: "Features", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"feat"}},
+		{Name: "Bug Fixes", SectionType: sv.ReleaseNotesSectionTypeCommits, CommitTypes: []string{"fix"}},
+	},
+}
+
+func tagCfg(pattern, filter string) sv.TagConfig {
+	return sv.TagConfig{Pattern: &pattern, Filter: &filter}
+}
+
+func messageProcessor() sv.MessageProcessor {
+	return sv.NewMessageProcessor(messageCfg, sv.BranchesConfig{})
+}
@@ -0,0 +1,139 @@
+package svtest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bvieira/sv4git/v2/sv"
+)
+
+// Repo temporary git repository with an "origin" bare remote, removed when test finishes.
+type Repo struct {
+	t    testing.TB
+	path string
+	now  time.Time
+}
+
+// NewRepo create a temporary git repository on "main" branch.
+func NewRepo(t testing.TB) *Repo {
+	t.Helper()
+
+	dir := t.TempDir()
+	r := &Repo{t: t, path: filepath.Join(dir, "repo"), now: DefaultDate}
+	origin := filepath.Join(dir, "origin.git")
+
+	r.run(dir, "init", "-q", "--bare", origin)
+	r.run(dir, "init", "-q", r.path)
+	r.Run("symbolic-ref", "HEAD", "refs/heads/"+defaultBranch)
+	r.Run("config", "user.name", defaultAuthorName)
+	r.Run("config", "user.email", defaultAuthorEmail)
+	r.Run("config", "commit.gpgsign", "false")
+	r.Run("config", "tag.gpgsign", "false")
+	r.Run("remote", "add", "origin", origin)
+	return r
+}
+
+// Path repository path.
+func (r *Repo) Path() string {
+	return r.path
+}
+
+// Repository sv.GitRepository for this repository, commits and tags created using it will use current date.
+func (r *Repo) Repository() sv.GitRepository {
+	return sv.GitRepository{Path: r.path, Env: isolatedEnv()}
+}
+
+// Git sv.GitImpl that runs on this repository.
+func (r *Repo) Git(messageProcessor sv.MessageProcessor, tcfg sv.TagConfig, lcfg sv.LogConfig) *sv.GitImpl {
+	return sv.NewGit(r.Repository(), messageProcessor, tcfg, lcfg)
+}
+
+// SetDate set date used by next commit or tag.
+func (r *Repo) SetDate(date time.Time) *Repo {
+	r.now = date
+	return r
+}
+
+// WriteFile write a file relative to repository path, it will be added on next commit.
+func (r *Repo) WriteFile(name, content string) *Repo {
+	r.t.Helper()
+
+	file := filepath.Join(r.path, name)
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		r.t.Fatalf("could not create directory for %s: %v", name, err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		r.t.Fatalf("could not write file %s: %v", name, err)
+	}
+	return r
+}
+
+// Commit add all changes and commit them using message, return commit hash.
+func (r *Repo) Commit(message string) string {
+	r.t.Helper()
+
+	r.Run("add", "-A")
+	r.Run("commit", "-q", "--allow-empty", "-m", message)
+	return r.Run("rev-parse", "HEAD")
+}
+
+// Tag create an annotated tag on HEAD.
+func (r *Repo) Tag(name string) *Repo {
+	r.t.Helper()
+
+	r.Run("tag", "-a", name, "-m", name)
+	return r
+}
+
+// Branch create a branch and check it out.
+func (r *Repo) Branch(name string) *Repo {
+	r.t.Helper()
+
+	r.Run("checkout", "-q", "-b", name)
+	return r
+}
+
+// Checkout check out a branch or revision.
+func (r *Repo) Checkout(revision string) *Repo {
+	r.t.Helper()
+
+	r.Run("checkout", "-q", revision)
+	return r
+}
+
+// Merge merge branch on current branch creating a merge commit using message, return commit hash.
+func (r *Repo) Merge(branch, message string) string {
+	r.t.Helper()
+
+	r.Run("merge", "-q", "--no-ff", branch, "-m", message)
+	return r.Run("rev-parse", "HEAD")
+}
+
+// Run execute a git command on repository and return trimmed output, test fails if command fails.
+func (r *Repo) Run(args ...string) string {
+	r.t.Helper()
+	return r.run(r.path, args...)
+}
+
+func (r *Repo) run(dir string, args ...string) string {
+	r.t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), isolatedEnv()...)
+	cmd.Env = append(cmd.Env, "GIT_AUTHOR_DATE="+r.now.Format(time.RFC3339), "GIT_COMMITTER_DATE="+r.now.Format(time.RFC3339))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	r.now = r.now.Add(time.Minute)
+	return strings.TrimSpace(string(out))
+}
+
+func isolatedEnv() []string {
+	return []string{"GIT_CONFIG_NOSYSTEM=1", "GIT_CONFIG_GLOBAL=" + os.DevNull, "GIT_TERMINAL_PROMPT=0"}
+}
@@ -0,0 +1,103 @@
+package svtest
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bvieira/sv4git/v2/sv"
+)
+
+func TestRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found on PATH")
+	}
+
+	r := NewRepo(t)
+	r.WriteFile("a.txt", "a\n").Commit("feat: first feature")
+	r.Tag("v1.0.0")
+	r.Branch("feature").WriteFile("b.txt", "b\nb\n").Commit("fix: some fix\n\njira: JIRA-1")
+	r.Checkout(defaultBranch).Merge("feature", "Merge branch 'feature'")
+
+	g := r.Git(messageProcessor(), tagCfg("v%d.%d.%d", ""), sv.LogConfig{Files: true, Merges: sv.LogMergesSkip})
+	if got := g.LastTag(); got != "v1.0.0" {
+		t.Errorf("LastTag() = %v, want v1.0.0", got)
+	}
+	if got := g.Branch(); got != defaultBranch {
+		t.Errorf("Branch() = %v, want %v", got, defaultBranch)
+	}
+
+	commits, err := g.Log(sv.NewLogRange(sv.TagRange, "v1.0.0", ""))
+	if err != nil {
+		t.Fatalf("Log() error = %v", err)
+	}
+	if len(commits) != 1 || commits[0].Message.Metadata["issue"] != "JIRA-1" {
+		t.Fatalf("Log() = %+v, want single fix commit", commits)
+	}
+	if want := "fix: some fix\n\njira: JIRA-1\n"; commits[0].RawMessage != want {
+		t.Errorf("Log() raw message = %q, want %q", commits[0].RawMessage, want)
+	}
+	if want := []sv.GitCommitFile{{Path: "b.txt", Additions: 2, Deletions: 0}}; !reflect.DeepEqual(commits[0].Files, want) {
+		t.Errorf("Log() files = %+v, want %+v", commits[0].Files, want)
+	}
+
+	current, _ := sv.ToVersion(g.LastTag())
+	next, _ := sv.NewSemVerCommitsProcessor(versioningCfg, messageCfg).NextVersion(current, commits)
+	if !next.Equal(semver.MustParse("1.0.1")) {
+		t.Fatalf("NextVersion() = %v, want 1.0.1", next)
+	}
+
+	if tag, err := g.Tag(*next); err != nil || tag != "v1.0.1" {
+		t.Fatalf("Tag() = %v, %v, want v1.0.1", tag, err)
+	}
+	if got := r.Run("ls-remote", "--tags", "origin", "v1.0.1"); got == "" {
+		t.Errorf("Tag() was not pushed to origin")
+	}
+	tags, err := g.Tags()
+	if err != nil || len(tags) != 2 || tags[1].Name != "v1.0.1" {
+		t.Errorf("Tags() = %+v, %v, want [v1.0.0 v1.0.1]", tags, err)
+	}
+}
+
+func TestRepo_logConfig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found on PATH")
+	}
+
+	r := NewRepo(t)
+	r.WriteFile("a.txt", "a\n").Commit("feat: first feature")
+	r.Branch("feature").WriteFile("b.txt", "b\n").Commit("fix: some fix")
+	r.Checkout(defaultBranch).Merge("feature", "Merge pull request #1 from x/feature\n\nfix: pull request title")
+
+	tests := []struct {
+		name    string
+		cfg     sv.LogConfig
+		want    []string
+		wantErr bool
+	}{
+		{"include merges", sv.LogConfig{Merges: sv.LogMergesInclude}, []string{"Merge pull request #1 from x/feature", "some fix", "first feature"}, false},
+		{"skip merges", sv.LogConfig{Merges: sv.LogMergesSkip}, []string{"some fix", "first feature"}, false},
+		{"only merges", sv.LogConfig{Merges: sv.LogMergesOnly}, []string{"Merge pull request #1 from x/feature"}, false},
+		{"merge body", sv.LogConfig{Merges: sv.LogMergesInclude, MergeBody: true}, []string{"pull request title", "some fix", "first feature"}, false},
+		{"first parent", sv.LogConfig{FirstParent: true}, []string{"Merge pull request #1 from x/feature", "first feature"}, false},
+		{"first parent with merge body", sv.LogConfig{FirstParent: true, MergeBody: true}, []string{"pull request title", "first feature"}, false},
+		{"invalid merges", sv.LogConfig{Merges: "none"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Lenient = true
+			commits, err := r.Git(messageProcessor(), tagCfg("v%d.%d.%d", ""), tt.cfg).Log(sv.NewLogRange(sv.TagRange, "", ""))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Log() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, commit := range commits {
+				got = append(got, commit.Message.Description)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Log() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}